@@ -0,0 +1,144 @@
+// Package animation decodes GIF, APNG, sprite sheet and PNG sequence
+// animations into frames and plays them on a fyne canvas.Image.
+package animation
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Animation is a decoded animation with every frame full size
+type Animation struct {
+	Frames []image.Image
+	Delays []time.Duration
+	Plays  int // times to play through, 0 repeats forever
+}
+
+// Decoder reads the animation called name from fsys
+type Decoder func(fsys fs.FS, name string) (*Animation, error)
+
+// Format is a registered animation file type
+type Format struct {
+	Name       string
+	Extensions []string                          // file name suffixes such as ".gif"
+	Sniff      func([]byte) bool                 // reports whether a file header is this format, may be nil
+	Dir        bool                              // decodes a directory rather than a file
+	SniffDir   func(fsys fs.FS, dir string) bool // reports whether a directory holds this format, may be nil
+	Decode     Decoder
+}
+
+// sniffLen is how much of a file is read to sniff its format
+const sniffLen = 512
+
+var (
+	formatsMu sync.RWMutex
+	formats   []Format
+)
+
+func init() {
+	RegisterFormat(Format{Name: "gif", Extensions: []string{".gif"}, Sniff: sniffGIF, Decode: decodeGIF})
+	RegisterFormat(Format{Name: "apng", Extensions: []string{".apng", ".png"}, Sniff: sniffPNG, Decode: decodeAPNG})
+	RegisterFormat(Format{Name: "sprite", Extensions: []string{spriteExt}, Decode: decodeSpriteSheet})
+	RegisterFormat(Format{Name: "sequence", Dir: true, SniffDir: sniffSequence, Decode: decodeSequence})
+	RegisterFormat(Format{Name: "webp", Extensions: []string{".webp"}, Sniff: sniffWebP, Decode: decodeWebP})
+}
+
+// RegisterFormat adds a format, formats registered later are tried first
+// so they can replace the built in ones
+func RegisterFormat(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats = append([]Format{f}, formats...)
+}
+
+// Load reads the animation at path, which may be a directory of frames
+func Load(path string) (*Animation, error) {
+	return Open(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+// Open reads the animation called name from fsys. The format is picked by
+// file extension, then by sniffing the file header.
+func Open(fsys fs.FS, name string) (*Animation, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := pickFormat(fsys, name, info.IsDir())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	a, err := f.Decode(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(a.Frames) == 0 {
+		return nil, fmt.Errorf("%s: animation has no frames", name)
+	}
+	return a, nil
+}
+
+func pickFormat(fsys fs.FS, name string, dir bool) (Format, error) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	if dir {
+		for _, f := range formats {
+			if f.Dir && (f.SniffDir == nil || f.SniffDir(fsys, name)) {
+				return f, nil
+			}
+		}
+		return Format{}, errors.New("no format reads this directory")
+	}
+
+	lower := strings.ToLower(path.Base(name))
+	for _, f := range formats {
+		for _, ext := range f.Extensions {
+			if strings.HasSuffix(lower, ext) {
+				return f, nil
+			}
+		}
+	}
+
+	header, err := readHeader(fsys, name)
+	if err != nil {
+		return Format{}, err
+	}
+	for _, f := range formats {
+		if f.Sniff != nil && f.Sniff(header) {
+			return f, nil
+		}
+	}
+	return Format{}, errors.New("unknown animation format")
+}
+
+func readHeader(fsys fs.FS, name string) ([]byte, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	header := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return header[:n], nil
+}
+
+// Still wraps a single image as a one frame animation
+func Still(img image.Image) *Animation {
+	return &Animation{Frames: []image.Image{img}, Delays: []time.Duration{0}}
+}
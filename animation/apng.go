@@ -0,0 +1,259 @@
+package animation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"io/fs"
+	"time"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// APNG frame disposal and blend operations, from the fcTL chunk
+const (
+	apngDisposeNone       = 0
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+
+	apngBlendSource = 0
+)
+
+// maxAPNGPixels caps the canvas checked before it is allocated, every
+// frame keeps a full copy of it
+const maxAPNGPixels = 4096 * 4096
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// apngFrame is one fcTL chunk and the image data that follows it
+type apngFrame struct {
+	width, height uint32
+	x, y          uint32
+	delay         time.Duration
+	dispose       byte
+	blend         byte
+	data          [][]byte
+}
+
+func sniffPNG(header []byte) bool {
+	return bytes.HasPrefix(header, pngSignature)
+}
+
+// decodeAPNG splits an APNG into plain PNGs, one per frame, and decodes
+// them with image/png. PNGs without an acTL chunk load as a still.
+func decodeAPNG(fsys fs.FS, name string) (*Animation, error) {
+	src, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := readPNGChunks(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 0 || chunks[0].typ != "IHDR" || len(chunks[0].data) != 13 {
+		return nil, errors.New("png: missing IHDR")
+	}
+	ihdr := chunks[0]
+
+	var shared []pngChunk // chunks every frame needs, such as the palette
+	var frames []*apngFrame
+	var current *apngFrame
+	plays := -1
+	seenIDAT := false
+
+	for _, c := range chunks[1:] {
+		switch c.typ {
+		case "acTL":
+			if len(c.data) != 8 {
+				return nil, errors.New("apng: bad acTL chunk")
+			}
+			plays = int(binary.BigEndian.Uint32(c.data[4:]))
+		case "fcTL":
+			if current, err = parseFCTL(c.data); err != nil {
+				return nil, err
+			}
+			frames = append(frames, current)
+		case "IDAT":
+			seenIDAT = true
+			// the default image is only a frame when a fcTL comes before it
+			if current != nil {
+				current.data = append(current.data, c.data)
+			}
+		case "fdAT":
+			if current == nil || len(c.data) < 4 {
+				return nil, errors.New("apng: fdAT before fcTL")
+			}
+			current.data = append(current.data, c.data[4:])
+		case "IEND":
+		default:
+			if !seenIDAT {
+				shared = append(shared, c)
+			}
+		}
+	}
+
+	if plays < 0 {
+		img, err := png.Decode(bytes.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		return Still(img), nil
+	}
+
+	width := int(binary.BigEndian.Uint32(ihdr.data[0:]))
+	height := int(binary.BigEndian.Uint32(ihdr.data[4:]))
+	if width == 0 || height == 0 || int64(width)*int64(height) > maxAPNGPixels {
+		return nil, fmt.Errorf("apng: %dx%d is too large to animate", width, height)
+	}
+	screen := image.NewRGBA(image.Rect(0, 0, width, height))
+	a := &Animation{Plays: plays}
+
+	for i, f := range frames {
+		// checked in int64 before decoding so a corrupt fcTL can neither
+		// wrap around nor make image/png allocate a huge frame
+		x0, y0 := int64(f.x), int64(f.y)
+		x1, y1 := x0+int64(f.width), y0+int64(f.height)
+		if f.width == 0 || f.height == 0 || x1 > int64(width) || y1 > int64(height) {
+			return nil, fmt.Errorf("apng frame %d: outside the image", i)
+		}
+		region := image.Rect(int(x0), int(y0), int(x1), int(y1))
+
+		img, err := f.decode(ihdr, shared)
+		if err != nil {
+			return nil, fmt.Errorf("apng frame %d: %w", i, err)
+		}
+
+		dispose := f.dispose
+		if i == 0 && dispose == apngDisposePrevious {
+			// nothing to go back to for the first frame
+			dispose = apngDisposeBackground
+		}
+
+		var previous *image.RGBA
+		if dispose == apngDisposePrevious {
+			previous = cloneRGBA(screen)
+		}
+
+		op := draw.Over
+		if f.blend == apngBlendSource {
+			op = draw.Src
+		}
+		draw.Draw(screen, region, img, img.Bounds().Min, op)
+
+		a.Frames = append(a.Frames, cloneRGBA(screen))
+		a.Delays = append(a.Delays, f.delay)
+
+		switch dispose {
+		case apngDisposeBackground:
+			draw.Draw(screen, region, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			screen = previous
+		}
+	}
+	return a, nil
+}
+
+func parseFCTL(data []byte) (*apngFrame, error) {
+	if len(data) != 26 {
+		return nil, errors.New("apng: bad fcTL chunk")
+	}
+
+	f := &apngFrame{
+		width:   binary.BigEndian.Uint32(data[4:]),
+		height:  binary.BigEndian.Uint32(data[8:]),
+		x:       binary.BigEndian.Uint32(data[12:]),
+		y:       binary.BigEndian.Uint32(data[16:]),
+		dispose: data[24],
+		blend:   data[25],
+	}
+
+	// the delay is a fraction of a second, a denominator of 0 means 100
+	num := time.Duration(binary.BigEndian.Uint16(data[20:]))
+	den := time.Duration(binary.BigEndian.Uint16(data[22:]))
+	if den == 0 {
+		den = 100
+	}
+	f.delay = num * time.Second / den
+	return f, nil
+}
+
+// decode rebuilds the frame as a standalone PNG
+func (f *apngFrame) decode(ihdr pngChunk, shared []pngChunk) (image.Image, error) {
+	var buf bytes.Buffer
+	buf.Write(pngSignature)
+
+	header := bytes.Clone(ihdr.data)
+	binary.BigEndian.PutUint32(header[0:], f.width)
+	binary.BigEndian.PutUint32(header[4:], f.height)
+	writePNGChunk(&buf, "IHDR", header)
+
+	for _, c := range shared {
+		writePNGChunk(&buf, c.typ, c.data)
+	}
+	for _, data := range f.data {
+		writePNGChunk(&buf, "IDAT", data)
+	}
+	writePNGChunk(&buf, "IEND", nil)
+
+	return png.Decode(&buf)
+}
+
+// readPNGChunks splits src into chunks up to IEND, checking their CRCs.
+// The chunks share src, a length longer than what is left of it is
+// rejected before anything is allocated.
+func readPNGChunks(src []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(src, pngSignature) {
+		return nil, errors.New("png: not a PNG file")
+	}
+	rest := src[len(pngSignature):]
+
+	var chunks []pngChunk
+	for {
+		if len(rest) < 8 {
+			return nil, fmt.Errorf("png: %w", io.ErrUnexpectedEOF)
+		}
+		length := uint64(binary.BigEndian.Uint32(rest[:4]))
+		typ := string(rest[4:8])
+		if length+4 > uint64(len(rest)-8) {
+			return nil, fmt.Errorf("png: %s chunk of %d bytes runs past the end of the file", typ, length)
+		}
+
+		data := rest[8 : 8+length]
+		sum := binary.BigEndian.Uint32(rest[8+length:])
+		crc := crc32.NewIEEE()
+		crc.Write(rest[4:8])
+		crc.Write(data)
+		if crc.Sum32() != sum {
+			return nil, fmt.Errorf("png: %s chunk: checksum mismatch", typ)
+		}
+		rest = rest[8+length+4:]
+
+		chunks = append(chunks, pngChunk{typ: typ, data: data})
+		if typ == "IEND" {
+			return chunks, nil
+		}
+	}
+}
+
+func writePNGChunk(w *bytes.Buffer, typ string, data []byte) {
+	var head [8]byte
+	binary.BigEndian.PutUint32(head[:4], uint32(len(data)))
+	copy(head[4:], typ)
+	w.Write(head[:])
+	w.Write(data)
+
+	crc := crc32.NewIEEE()
+	crc.Write(head[4:])
+	crc.Write(data)
+	binary.Write(w, binary.BigEndian, crc.Sum32())
+}
@@ -0,0 +1,194 @@
+package animation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// encodeTestPNG fills a w x 1 image with c and returns its chunks
+func encodeTestPNG(t *testing.T, w int, c color.Color) []pngChunk {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, 1))
+	for x := range w {
+		img.Set(x, 0, c)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	chunks, err := readPNGChunks(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chunks
+}
+
+func chunkData(chunks []pngChunk, typ string) []byte {
+	for _, c := range chunks {
+		if c.typ == typ {
+			return c.data
+		}
+	}
+	return nil
+}
+
+func testFCTL(seq, width, x uint32, delayMS uint16) []byte {
+	data := make([]byte, 26)
+	binary.BigEndian.PutUint32(data[0:], seq)
+	binary.BigEndian.PutUint32(data[4:], width)
+	binary.BigEndian.PutUint32(data[8:], 1)
+	binary.BigEndian.PutUint32(data[12:], x)
+	binary.BigEndian.PutUint16(data[20:], delayMS)
+	binary.BigEndian.PutUint16(data[22:], 1000)
+	data[24] = apngDisposeNone
+	data[25] = apngBlendSource
+	return data
+}
+
+// encodeTestAPNG is a 4x1 red frame followed by a blue frame over the
+// right half
+func encodeTestAPNG(t *testing.T) []byte {
+	t.Helper()
+	red := encodeTestPNG(t, 4, testRed)
+	blue := encodeTestPNG(t, 2, testBlue)
+
+	var buf bytes.Buffer
+	buf.Write(pngSignature)
+	writePNGChunk(&buf, "IHDR", chunkData(red, "IHDR"))
+	writePNGChunk(&buf, "acTL", []byte{0, 0, 0, 2, 0, 0, 0, 3})
+	writePNGChunk(&buf, "fcTL", testFCTL(0, 4, 0, 100))
+	writePNGChunk(&buf, "IDAT", chunkData(red, "IDAT"))
+	writePNGChunk(&buf, "fcTL", testFCTL(1, 2, 2, 250))
+	writePNGChunk(&buf, "fdAT", append([]byte{0, 0, 0, 2}, chunkData(blue, "IDAT")...))
+	writePNGChunk(&buf, "IEND", nil)
+	return buf.Bytes()
+}
+
+func TestReadPNGChunks(t *testing.T) {
+	chunks, err := readPNGChunks(encodeTestAPNG(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, c := range chunks {
+		types = append(types, c.typ)
+	}
+	want := []string{"IHDR", "acTL", "fcTL", "IDAT", "fcTL", "fdAT", "IEND"}
+	if !slices.Equal(types, want) {
+		t.Errorf("chunks: got %v, want %v", types, want)
+	}
+}
+
+func TestReadPNGChunksCorrupt(t *testing.T) {
+	src := encodeTestAPNG(t)
+	ihdr := len(pngSignature)
+
+	huge := bytes.Clone(src)
+	binary.BigEndian.PutUint32(huge[ihdr:], 0x7fffffff)
+
+	badCRC := bytes.Clone(src)
+	badCRC[ihdr+8] ^= 0xff
+
+	tests := []struct {
+		name string
+		src  []byte
+		want string
+	}{
+		{"not a png", []byte("GIF89a"), "not a PNG"},
+		{"huge length", huge, "runs past the end of the file"},
+		{"length into the crc", src[:ihdr+8+13+2], "runs past the end of the file"},
+		{"bad crc", badCRC, "checksum mismatch"},
+		{"no IEND", src[:len(src)-12], "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		_, err := readPNGChunks(tt.src)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeAPNG(t *testing.T) {
+	fsys := fstest.MapFS{"a.png": {Data: encodeTestAPNG(t)}}
+	a, err := decodeAPNG(fsys, "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Plays != 3 {
+		t.Errorf("plays: got %d, want 3", a.Plays)
+	}
+	wantDelays := []time.Duration{100 * time.Millisecond, 250 * time.Millisecond}
+	if !slices.Equal(a.Delays, wantDelays) {
+		t.Errorf("delays: got %v, want %v", a.Delays, wantDelays)
+	}
+
+	want := [][]color.RGBA{
+		{testRed, testRed, testRed, testRed},
+		{testRed, testRed, testBlue, testBlue},
+	}
+	if len(a.Frames) != len(want) {
+		t.Fatalf("frames: got %d, want %d", len(a.Frames), len(want))
+	}
+	for i, frame := range a.Frames {
+		if got := rowColors(frame); !slices.Equal(got, want[i]) {
+			t.Errorf("frame %d: got %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestDecodeAPNGStill(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 1))); err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{"still.png": {Data: buf.Bytes()}}
+	a, err := decodeAPNG(fsys, "still.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Frames) != 1 {
+		t.Errorf("frames: got %d, want 1", len(a.Frames))
+	}
+}
+
+func TestDecodeAPNGBadFrameRegion(t *testing.T) {
+	red := encodeTestPNG(t, 4, testRed)
+	tests := []struct {
+		name          string
+		width, height uint32
+		x             uint32
+	}{
+		{"wider than the image", 5, 1, 0},
+		{"huge", 0xffff_ffff, 0xffff_ffff, 0},
+		{"offset wraps around", 2, 1, 0xffff_ffff},
+		{"empty", 0, 1, 0},
+	}
+	for _, tt := range tests {
+		fctl := testFCTL(0, tt.width, tt.x, 100)
+		binary.BigEndian.PutUint32(fctl[8:], tt.height)
+
+		var buf bytes.Buffer
+		buf.Write(pngSignature)
+		writePNGChunk(&buf, "IHDR", chunkData(red, "IHDR"))
+		writePNGChunk(&buf, "acTL", []byte{0, 0, 0, 1, 0, 0, 0, 0})
+		writePNGChunk(&buf, "fcTL", fctl)
+		writePNGChunk(&buf, "IDAT", chunkData(red, "IDAT"))
+		writePNGChunk(&buf, "IEND", nil)
+
+		fsys := fstest.MapFS{"a.png": {Data: buf.Bytes()}}
+		_, err := decodeAPNG(fsys, "a.png")
+		if err == nil || !strings.Contains(err.Error(), "outside the image") {
+			t.Errorf("%s: got %v, want the frame rejected as outside the image", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package animation
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io/fs"
+	"time"
+)
+
+func sniffGIF(header []byte) bool {
+	return bytes.HasPrefix(header, []byte("GIF87a")) || bytes.HasPrefix(header, []byte("GIF89a"))
+}
+
+func decodeGIF(fsys fs.FS, name string) (*Animation, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	g, err := gif.DecodeAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Animation{Plays: gifPlays(g.LoopCount)}
+	for i, frame := range compositeGIF(g) {
+		a.Frames = append(a.Frames, frame)
+		// GIF delays are in 100ths of a second
+		a.Delays = append(a.Delays, time.Duration(g.Delay[i])*10*time.Millisecond)
+	}
+	return a, nil
+}
+
+// gifPlays converts gif.GIF.LoopCount, where 0 loops forever, -1 plays once
+// and n plays n+1 times
+func gifPlays(loopCount int) int {
+	switch {
+	case loopCount == 0:
+		return 0
+	case loopCount < 0:
+		return 1
+	}
+	return loopCount + 1
+}
+
+// compositeGIF draws each frame at its offset onto a full size canvas,
+// GIF frames only hold the part of the picture that changed. What is left
+// for the next frame follows the frame's disposal method.
+func compositeGIF(g *gif.GIF) []image.Image {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		// no logical screen size, fit every frame instead
+		for _, frame := range g.Image {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+
+	screen := image.NewRGBA(bounds)
+	frames := make([]image.Image, len(g.Image))
+	for i, frame := range g.Image {
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(screen)
+		}
+
+		draw.Draw(screen, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		frames[i] = cloneRGBA(screen)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			// cleared to transparent like browsers do, the background colour
+			// is opaque even in GIFs whose frames are see through
+			draw.Draw(screen, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			screen = previous
+		}
+	}
+	return frames
+}
+
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
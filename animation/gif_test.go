@@ -0,0 +1,96 @@
+package animation
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+var (
+	testRed  = color.RGBA{R: 255, A: 255}
+	testBlue = color.RGBA{B: 255, A: 255}
+)
+
+// encodeTestGIF writes a 4x1 GIF with an opaque black background colour
+// in its global palette
+func encodeTestGIF(t *testing.T, frames []*image.Paletted, disposal []byte) []byte {
+	t.Helper()
+	palette := color.Palette{color.Transparent, testRed, testBlue, color.Black}
+	g := &gif.GIF{
+		Image:           frames,
+		Delay:           make([]int, len(frames)),
+		Disposal:        disposal,
+		BackgroundIndex: 3,
+		Config:          image.Config{ColorModel: palette, Width: 4, Height: 1},
+	}
+	for _, frame := range frames {
+		frame.Palette = palette
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func testFrame(x0, x1 int, index uint8) *image.Paletted {
+	frame := image.NewPaletted(image.Rect(x0, 0, x1, 1), nil)
+	for i := range frame.Pix {
+		frame.Pix[i] = index
+	}
+	return frame
+}
+
+func rowColors(img image.Image) []color.RGBA {
+	var row []color.RGBA
+	for x := range 4 {
+		row = append(row, color.RGBAModel.Convert(img.At(x, 0)).(color.RGBA))
+	}
+	return row
+}
+
+func TestDecodeGIFDisposal(t *testing.T) {
+	first := testFrame(0, 4, 0)
+	first.Pix[0], first.Pix[1] = 1, 1
+
+	src := encodeTestGIF(t, []*image.Paletted{
+		first,
+		testFrame(1, 2, 2),
+		testFrame(3, 4, 2),
+	}, []byte{gif.DisposalBackground, gif.DisposalPrevious, gif.DisposalNone})
+
+	a, err := decodeGIF(fstest.MapFS{"test.gif": {Data: src}}, "test.gif")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clear := color.RGBA{}
+	want := [][]color.RGBA{
+		{testRed, testRed, clear, clear},
+		// the background disposal clears to transparent, not black
+		{clear, testBlue, clear, clear},
+		// the previous disposal puts back the cleared screen
+		{clear, clear, clear, testBlue},
+	}
+	if len(a.Frames) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(a.Frames), len(want))
+	}
+	for i, frame := range a.Frames {
+		if got := rowColors(frame); !slices.Equal(got, want[i]) {
+			t.Errorf("frame %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestGIFPlays(t *testing.T) {
+	for loopCount, want := range map[int]int{0: 0, -1: 1, 1: 2, 4: 5} {
+		if got := gifPlays(loopCount); got != want {
+			t.Errorf("gifPlays(%d) = %d, want %d", loopCount, got, want)
+		}
+	}
+}
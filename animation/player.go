@@ -0,0 +1,231 @@
+package animation
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// minFrameDelay matches browsers, which play delays of 10ms or less at
+// 100ms so zero delay frames do not spin
+const (
+	minFrameDelay     = 10 * time.Millisecond
+	clampedFrameDelay = 100 * time.Millisecond
+)
+
+// Player shows an Animation on a canvas.Image
+type Player struct {
+	Image  *canvas.Image
+	frames []image.Image
+	delays []time.Duration
+	plays  int
+
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	wake      chan struct{} // tells the player the frame or timing changed
+	frame     int
+	completed int // passes through the frames since Start
+	paused    bool
+	speed     float64
+
+	// clock sync, see SetTempo
+	tempo   time.Duration
+	offsets []time.Duration // start of each frame within a loop
+	loop    time.Duration   // length of one loop at normal speed
+}
+
+// NewPlayer prepares a to play, showing its first frame
+func NewPlayer(a *Animation) *Player {
+	img := canvas.NewImageFromImage(a.Frames[0])
+	img.FillMode = canvas.ImageFillOriginal
+
+	delays := make([]time.Duration, len(a.Delays))
+	for i, delay := range a.Delays {
+		delays[i] = clampDelay(delay)
+	}
+
+	return &Player{
+		Image:  img,
+		frames: a.Frames,
+		delays: delays,
+		plays:  a.Plays,
+		wake:   make(chan struct{}, 1),
+		speed:  1,
+	}
+}
+
+// NewPlaceholder is a still broken image icon, shown when an animation
+// cannot be loaded
+func NewPlaceholder() *Player {
+	img := canvas.NewImageFromResource(theme.BrokenImageIcon())
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(64, 64))
+
+	return &Player{Image: img, wake: make(chan struct{}, 1), speed: 1}
+}
+
+func clampDelay(delay time.Duration) time.Duration {
+	if delay <= minFrameDelay {
+		return clampedFrameDelay
+	}
+	return delay
+}
+
+// Start begins animating until ctx is done, Stop is called or the
+// animation's play count runs out. Starting again restarts the count.
+func (p *Player) Start(ctx context.Context) {
+	// nothing to animate for single frames and placeholders
+	if len(p.frames) < 2 {
+		return
+	}
+
+	p.mu.Lock()
+	if p.tempo > 0 {
+		// the clock drives the frames
+		p.mu.Unlock()
+		return
+	}
+	if p.cancel != nil {
+		p.cancel()
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.completed = 0
+	p.mu.Unlock()
+
+	go p.run(ctx)
+}
+
+// Stop ends the animation, leaving the current frame on screen
+func (p *Player) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// Pause holds the current frame until Resume
+func (p *Player) Pause() {
+	p.mu.Lock()
+	p.paused = true
+	p.mu.Unlock()
+	p.notify()
+}
+
+func (p *Player) Resume() {
+	p.mu.Lock()
+	p.paused = false
+	p.mu.Unlock()
+	p.notify()
+}
+
+// SetSpeed scales playback, 2 plays twice as fast and 0.5 at half speed
+func (p *Player) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("speed must be above 0, got %v", speed)
+	}
+
+	p.mu.Lock()
+	p.speed = speed
+	p.mu.Unlock()
+	p.notify()
+	return nil
+}
+
+// SeekFrame shows frame i and continues playing from there
+func (p *Player) SeekFrame(i int) error {
+	if i < 0 || i >= len(p.frames) {
+		return fmt.Errorf("frame %d out of range, animation has %d frames", i, len(p.frames))
+	}
+
+	p.mu.Lock()
+	p.frame = i
+	p.mu.Unlock()
+
+	p.show(i)
+	p.notify()
+	return nil
+}
+
+func (p *Player) run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		// a nil channel blocks, so a paused player only wakes on changes
+		var next <-chan time.Time
+		if delay, ok := p.nextDelay(); ok {
+			timer.Reset(delay)
+			next = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+			// re-arm with the new frame or speed
+			timer.Stop()
+		case <-next:
+			frame, ok := p.advance()
+			if !ok {
+				return
+			}
+			p.show(frame)
+		}
+	}
+}
+
+// nextDelay is how long the current frame stays up, false while paused
+func (p *Player) nextDelay() (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused {
+		return 0, false
+	}
+	return time.Duration(float64(p.delays[p.frame]) / p.speed), true
+}
+
+// advance moves to the next frame, false once the play count is used up
+func (p *Player) advance() (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.frame+1 < len(p.frames) {
+		p.frame++
+		return p.frame, true
+	}
+
+	p.completed++
+	if p.plays > 0 && p.completed >= p.plays {
+		// stay on the last frame
+		return p.frame, false
+	}
+	p.frame = 0
+	return p.frame, true
+}
+
+func (p *Player) show(frame int) {
+	img := p.frames[frame]
+	fyne.Do(func() {
+		// swap frame image
+		p.Image.Image = img
+		p.Image.Refresh()
+	})
+}
+
+func (p *Player) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
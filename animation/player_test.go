@@ -0,0 +1,169 @@
+package animation
+
+import (
+	"context"
+	"image"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// testAnimation is a blank 1x1 frame for each delay
+func testAnimation(plays int, delays ...time.Duration) *Animation {
+	a := &Animation{Plays: plays, Delays: delays}
+	for range delays {
+		a.Frames = append(a.Frames, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	}
+	return a
+}
+
+// frameChange is the player moving to frame at a time since the start
+type frameChange struct {
+	at    time.Duration
+	frame int
+}
+
+// playFor steps p the way run does, with a fake clock instead of a timer,
+// until it stops or limit has passed. It reports whether it stopped.
+func playFor(p *Player, limit time.Duration) ([]frameChange, bool) {
+	var changes []frameChange
+	var now time.Duration
+	for {
+		delay, ok := p.nextDelay()
+		if !ok || now+delay > limit {
+			return changes, false
+		}
+		now += delay
+
+		frame, ok := p.advance()
+		if !ok {
+			return changes, true
+		}
+		changes = append(changes, frameChange{now, frame})
+	}
+}
+
+func TestClampDelay(t *testing.T) {
+	tests := []struct {
+		delay, want time.Duration
+	}{
+		{0, clampedFrameDelay},
+		{10 * time.Millisecond, clampedFrameDelay},
+		{11 * time.Millisecond, 11 * time.Millisecond},
+		{50 * time.Millisecond, 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := clampDelay(tt.delay); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.delay, got, tt.want)
+		}
+	}
+
+	p := NewPlayer(testAnimation(0, 0, 5*time.Millisecond, 20*time.Millisecond))
+	if want := []time.Duration{clampedFrameDelay, clampedFrameDelay, 20 * time.Millisecond}; !slices.Equal(p.delays, want) {
+		t.Errorf("player delays: got %v, want %v", p.delays, want)
+	}
+}
+
+func TestPlayerPlayback(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name    string
+		plays   int
+		speed   float64
+		limit   time.Duration
+		want    []frameChange
+		stopped bool
+	}{
+		{"once", 1, 1, time.Hour, []frameChange{{100 * ms, 1}, {300 * ms, 2}}, true},
+		{"twice", 2, 1, time.Hour, []frameChange{
+			{100 * ms, 1}, {300 * ms, 2}, {600 * ms, 0}, {700 * ms, 1}, {900 * ms, 2},
+		}, true},
+		{"forever", 0, 1, 800 * ms, []frameChange{
+			{100 * ms, 1}, {300 * ms, 2}, {600 * ms, 0}, {700 * ms, 1},
+		}, false},
+		{"double speed", 1, 2, time.Hour, []frameChange{{50 * ms, 1}, {150 * ms, 2}}, true},
+		{"half speed", 1, 0.5, time.Hour, []frameChange{{200 * ms, 1}, {600 * ms, 2}}, true},
+	}
+	for _, tt := range tests {
+		p := NewPlayer(testAnimation(tt.plays, 100*ms, 200*ms, 300*ms))
+		if err := p.SetSpeed(tt.speed); err != nil {
+			t.Fatal(err)
+		}
+
+		got, stopped := playFor(p, tt.limit)
+		if !slices.Equal(got, tt.want) || stopped != tt.stopped {
+			t.Errorf("%s: got %v stopped %v, want %v stopped %v", tt.name, got, stopped, tt.want, tt.stopped)
+		}
+		if tt.stopped && p.frame != 2 {
+			t.Errorf("%s: stopped on frame %d, want the last frame", tt.name, p.frame)
+		}
+	}
+}
+
+func TestPlayerPauseResume(t *testing.T) {
+	test.NewTempApp(t)
+	ms := time.Millisecond
+	p := NewPlayer(testAnimation(1, 100*ms, 200*ms, 300*ms))
+
+	if err := p.SeekFrame(1); err != nil {
+		t.Fatal(err)
+	}
+	if p.Image.Image != p.frames[1] {
+		t.Error("SeekFrame did not show frame 1")
+	}
+
+	p.Pause()
+	if changes, _ := playFor(p, time.Hour); len(changes) != 0 {
+		t.Errorf("paused player moved: %v", changes)
+	}
+
+	// resumes from the paused frame with its full delay
+	p.Resume()
+	got, stopped := playFor(p, time.Hour)
+	if want := []frameChange{{200 * ms, 2}}; !slices.Equal(got, want) || !stopped {
+		t.Errorf("after resume: got %v stopped %v, want %v stopped", got, stopped, want)
+	}
+}
+
+func TestPlayerErrors(t *testing.T) {
+	p := NewPlayer(testAnimation(0, 0, 0))
+	for _, speed := range []float64{0, -1} {
+		if err := p.SetSpeed(speed); err == nil {
+			t.Errorf("speed %v: got no error", speed)
+		}
+	}
+	for _, frame := range []int{-1, 2} {
+		if err := p.SeekFrame(frame); err == nil {
+			t.Errorf("frame %d: got no error", frame)
+		}
+	}
+}
+
+func TestPlayerStartStop(t *testing.T) {
+	test.NewTempApp(t)
+	p := NewPlayer(testAnimation(0, 0, 0))
+
+	p.Start(context.Background())
+	p.mu.Lock()
+	running := p.cancel != nil
+	p.mu.Unlock()
+	if !running {
+		t.Fatal("Start did not start the player")
+	}
+
+	p.Stop()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		t.Error("Stop left the player running")
+	}
+
+	// a single frame has nothing to animate
+	still := NewPlayer(testAnimation(0, 0))
+	still.Start(context.Background())
+	if still.cancel != nil {
+		t.Error("a still started playing")
+	}
+}
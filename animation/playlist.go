@@ -0,0 +1,265 @@
+package animation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"temp.com/go-clock/clock"
+)
+
+// Schedule picks when a Playlist moves on to the next animation. Every
+// is counted from local midnight, so 15m switches at :00, :15, :30 and
+// :45 and 1h switches on the hour. At lists times of day instead.
+type Schedule struct {
+	Every time.Duration
+	At    []time.Duration // since midnight, sorted
+}
+
+// ParseSchedule reads an interval such as "15m" or times of day such as
+// "09:00", only one of the two may be given
+func ParseSchedule(every string, at []string) (Schedule, error) {
+	var s Schedule
+	switch {
+	case every != "" && len(at) > 0:
+		return s, errors.New("use either every or at, not both")
+	case every != "":
+		d, err := time.ParseDuration(every)
+		if err != nil || d < time.Second {
+			return s, fmt.Errorf("invalid interval %q, want a duration of 1s or more such as 15m", every)
+		}
+		s.Every = d
+	case len(at) > 0:
+		for _, value := range at {
+			tod, err := time.Parse("15:04", value)
+			if err != nil {
+				return s, fmt.Errorf("invalid time of day %q, want HH:MM", value)
+			}
+			s.At = append(s.At, time.Duration(tod.Hour())*time.Hour+time.Duration(tod.Minute())*time.Minute)
+		}
+		slices.Sort(s.At)
+		s.At = slices.Compact(s.At)
+	default:
+		return s, errors.New("a playlist needs an interval or times of day to switch at")
+	}
+	return s, nil
+}
+
+// slot counts how many switches have happened up to t, it only depends on
+// t so a restarted playlist picks up where it was
+func (s Schedule) slot(t time.Time) int64 {
+	local := wallNanos(t)
+
+	if s.Every > 0 {
+		return local / int64(s.Every)
+	}
+
+	days := local / int64(24*time.Hour)
+	sinceMidnight := time.Duration(local % int64(24*time.Hour))
+	passed := 0
+	for _, at := range s.At {
+		if sinceMidnight >= at {
+			passed++
+		}
+	}
+	return days*int64(len(s.At)) + int64(passed)
+}
+
+// wallNanos is t as nanoseconds of local wall time since the Unix epoch,
+// so whole days, hours and minutes line up with the zone's clock
+func wallNanos(t time.Time) int64 {
+	_, offset := t.Zone()
+	return t.UnixNano() + int64(offset)*int64(time.Second)
+}
+
+// Playlist rotates through the animations in a directory, fading from one
+// to the next. Register it with a clock.Scheduler to drive the switching.
+type Playlist struct {
+	Container *fyne.Container
+	Schedule  Schedule
+	Crossfade time.Duration
+	Tempo     time.Duration // see Player.SetTempo, 0 runs free
+
+	dir   string
+	names []string
+
+	mu      sync.Mutex
+	ctx     context.Context // done once the playlist is stopped
+	cancel  context.CancelFunc
+	index   int
+	current *Player
+}
+
+// NewPlaylist lists the animations in dir. Files of unknown formats,
+// directories without numbered PNGs and the images of sprite sheets are
+// left out.
+func NewPlaylist(dir string, schedule Schedule, crossfade time.Duration) (*Playlist, error) {
+	fsys := os.DirFS(dir)
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	// sheet images are shown through their descriptor, not on their own
+	sheets := map[string]bool{}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(strings.ToLower(e.Name()), spriteExt) {
+			if sheet, err := readSpriteSheet(fsys, e.Name()); err == nil {
+				sheets[sheet.imagePath(e.Name())] = true
+			}
+		}
+	}
+
+	var names []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") || sheets[e.Name()] {
+			continue
+		}
+		if _, err := pickFormat(fsys, e.Name(), e.IsDir()); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s: no animations found", dir)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Playlist{
+		Container: container.NewStack(),
+		Schedule:  schedule,
+		Crossfade: crossfade,
+		dir:       dir,
+		names:     names,
+		index:     -1,
+		ctx:       ctx,
+		cancel:    cancel,
+	}, nil
+}
+
+// Start shows the animation scheduled for now and plays it until ctx is
+// done or Stop is called
+func (p *Playlist) Start(ctx context.Context, now time.Time) {
+	p.mu.Lock()
+	p.cancel()
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	p.index = -1 // reload the scheduled animation after a Stop
+	p.mu.Unlock()
+
+	p.sync(now)
+}
+
+// Stop ends the current animation and the schedule, later updates do
+// nothing until Start is called again
+func (p *Playlist) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cancel()
+	if p.current != nil {
+		p.current.Stop()
+	}
+}
+
+// Update switches animation when the schedule says so and forwards t to
+// the current player for tempo sync
+func (p *Playlist) Update(t *clock.TickData) {
+	p.sync(t.Time)
+
+	p.mu.Lock()
+	current := p.current
+	stopped := p.ctx.Err() != nil
+	p.mu.Unlock()
+
+	if current != nil && !stopped {
+		current.Update(t)
+	}
+}
+
+// sync starts loading the animation scheduled for now if it is not showing
+func (p *Playlist) sync(now time.Time) {
+	index := int(p.Schedule.slot(now) % int64(len(p.names)))
+
+	p.mu.Lock()
+	if p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+	switching := index != p.index
+	p.index = index
+	p.mu.Unlock()
+
+	if switching {
+		// decoding can take a while, keep it off the main goroutine
+		go p.load(index)
+	}
+}
+
+func (p *Playlist) load(index int) {
+	name := p.names[index]
+	player := NewPlaceholder()
+	if a, err := Load(filepath.Join(p.dir, name)); err != nil {
+		fyne.LogError("could not load animation, showing placeholder", err)
+	} else {
+		player = NewPlayer(a)
+	}
+
+	fyne.Do(func() {
+		p.mu.Lock()
+		if p.index != index || p.ctx.Err() != nil {
+			// the schedule moved on or the playlist stopped while this
+			// was loading
+			p.mu.Unlock()
+			return
+		}
+		previous := p.current
+		p.current = player
+		ctx := p.ctx
+		p.mu.Unlock()
+
+		if p.Tempo > 0 {
+			player.SetTempo(p.Tempo)
+		}
+		player.Start(ctx)
+		p.fadeIn(player, previous)
+	})
+}
+
+// fadeIn stacks next on top and crossfades to it, then drops previous
+func (p *Playlist) fadeIn(next, previous *Player) {
+	p.Container.Add(next.Image)
+	if previous == nil || p.Crossfade <= 0 {
+		p.drop(previous)
+		return
+	}
+
+	next.Image.Translucency = 1
+	fade := fyne.NewAnimation(p.Crossfade, func(done float32) {
+		next.Image.Translucency = 1 - float64(done)
+		previous.Image.Translucency = float64(done)
+		next.Image.Refresh()
+		previous.Image.Refresh()
+
+		if done == 1 {
+			p.drop(previous)
+		}
+	})
+	fade.Curve = fyne.AnimationEaseInOut
+	fade.Start()
+}
+
+func (p *Playlist) drop(previous *Player) {
+	if previous == nil {
+		return
+	}
+	previous.Stop()
+	p.Container.Remove(previous.Image)
+}
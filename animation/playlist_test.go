@@ -0,0 +1,124 @@
+package animation
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"temp.com/go-clock/clock"
+)
+
+func newTestPlaylist(t *testing.T) *Playlist {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"a.gif", "b.gif"} {
+		src := encodeTestGIF(t, []*image.Paletted{testFrame(0, 4, 1), testFrame(0, 4, 2)}, nil)
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := NewPlaylist(dir, Schedule{Every: time.Second}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// waitForPlayer waits for the background load to replace previous
+func waitForPlayer(t *testing.T, p *Playlist, previous *Player) *Player {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		p.mu.Lock()
+		current := p.current
+		p.mu.Unlock()
+		if current != nil && current != previous {
+			return current
+		}
+	}
+	t.Fatal("no animation loaded")
+	return nil
+}
+
+func TestPlaylistStop(t *testing.T) {
+	test.NewTempApp(t)
+
+	base := time.Date(2024, 10, 18, 9, 0, 0, 0, time.UTC)
+	p := newTestPlaylist(t)
+	// load on this goroutine, fyne.Do runs straight away in tests
+	p.index = 0
+	p.load(0)
+	first := p.current
+	if first == nil {
+		t.Fatal("no animation loaded")
+	}
+
+	p.Stop()
+	p.Update(clock.NewTickData(clock.NewFixedClock(base.Add(time.Second)), time.UTC))
+
+	p.mu.Lock()
+	index, current := p.index, p.current
+	p.mu.Unlock()
+	if index != 0 || current != first {
+		t.Errorf("update after Stop switched to animation %d", index)
+	}
+
+	// a load that was already running when Stop was called
+	p.load(index)
+	if p.current != first {
+		t.Error("a load finishing after Stop started a new player")
+	}
+
+	p.Start(context.Background(), base.Add(time.Second))
+	waitForPlayer(t, p, first)
+	p.Stop()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.index != 1 {
+		t.Errorf("restarted on animation %d, want 1", p.index)
+	}
+}
+
+func TestNewPlaylistEntries(t *testing.T) {
+	var sheet bytes.Buffer
+	if err := png.Encode(&sheet, image.NewRGBA(image.Rect(0, 0, 4, 1))); err != nil {
+		t.Fatal(err)
+	}
+	gif := encodeTestGIF(t, []*image.Paletted{testFrame(0, 4, 1)}, nil)
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"a.gif":              gif,
+		"walk.png":           sheet.Bytes(),
+		"walk.sprite.toml":   []byte("image = \"walk.png\"\nframe_width = 1\nframe_height = 1\n"),
+		"seq/frame1.png":     sheet.Bytes(),
+		"notes/readme.txt":   []byte("not an animation"),
+		"covers/cover.png":   sheet.Bytes(),
+		".hidden/frame1.png": sheet.Bytes(),
+	}
+	for name, data := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := NewPlaylist(dir, Schedule{Every: time.Second}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a.gif", "seq", "walk.sprite.toml"}; !slices.Equal(p.names, want) {
+		t.Errorf("got %v, want %v", p.names, want)
+	}
+}
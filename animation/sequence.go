@@ -0,0 +1,91 @@
+package animation
+
+import (
+	"errors"
+	"fmt"
+	"image/png"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SequenceDelay is how long each frame of a PNG sequence is shown
+var SequenceDelay = 100 * time.Millisecond
+
+// sequenceFrame is a numbered PNG in a sequence directory
+type sequenceFrame struct {
+	name   string
+	number int
+}
+
+// sequenceFrames lists the numbered PNGs in dir in numeric order
+func sequenceFrames(fsys fs.FS, dir string) ([]sequenceFrame, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []sequenceFrame
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(path.Ext(e.Name()), ".png") {
+			continue
+		}
+		if n, ok := frameNumber(e.Name()); ok {
+			files = append(files, sequenceFrame{e.Name(), n})
+		}
+	}
+	slices.SortFunc(files, func(a, b sequenceFrame) int { return a.number - b.number })
+	return files, nil
+}
+
+// sniffSequence reports whether dir holds at least one numbered PNG
+func sniffSequence(fsys fs.FS, dir string) bool {
+	files, err := sequenceFrames(fsys, dir)
+	return err == nil && len(files) > 0
+}
+
+// decodeSequence reads a directory of numbered PNGs such as frame1.png,
+// frame2.png ... frame10.png. Frames play in numeric order.
+func decodeSequence(fsys fs.FS, dir string) (*Animation, error) {
+	files, err := sequenceFrames(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no numbered .png frames in directory")
+	}
+
+	a := &Animation{}
+	for _, f := range files {
+		file, err := fsys.Open(path.Join(dir, f.name))
+		if err != nil {
+			return nil, err
+		}
+		img, err := png.Decode(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+
+		a.Frames = append(a.Frames, img)
+		a.Delays = append(a.Delays, SequenceDelay)
+	}
+	return a, nil
+}
+
+// frameNumber is the last run of digits in a file name, "walk_012.png" is 12
+func frameNumber(name string) (int, bool) {
+	stem := strings.TrimSuffix(name, path.Ext(name))
+	end := strings.LastIndexFunc(stem, unicode.IsDigit) + 1
+	if end == 0 {
+		return 0, false
+	}
+	start := strings.LastIndexFunc(stem[:end], func(r rune) bool { return !unicode.IsDigit(r) }) + 1
+
+	n, err := strconv.Atoi(stem[start:end])
+	return n, err == nil
+}
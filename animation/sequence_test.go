@@ -0,0 +1,66 @@
+package animation
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFrameNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+		ok   bool
+	}{
+		{"frame1.png", 1, true},
+		{"frame10.png", 10, true},
+		{"walk_012.png", 12, true},
+		{"2x_walk_07.png", 7, true},
+		{"7.png", 7, true},
+		{"v2_final.png", 2, true},
+		{"cover.png", 0, false},
+		{"frame.png", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := frameNumber(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("frameNumber(%q): got %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDecodeSequenceOrder(t *testing.T) {
+	encode := func(shade uint8) []byte {
+		img := image.NewGray(image.Rect(0, 0, 1, 1))
+		img.SetGray(0, 0, color.Gray{Y: shade})
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	fsys := fstest.MapFS{
+		"walk/frame10.png": {Data: encode(10)},
+		"walk/frame2.png":  {Data: encode(2)},
+		"walk/frame1.png":  {Data: encode(1)},
+		"walk/notes.txt":   {Data: []byte("not a frame")},
+		"walk/cover.png":   {Data: encode(99)},
+	}
+
+	a, err := decodeSequence(fsys, "walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint8{1, 2, 10}
+	if len(a.Frames) != len(want) {
+		t.Fatalf("frames: got %d, want %d", len(a.Frames), len(want))
+	}
+	for i, frame := range a.Frames {
+		if got := color.GrayModel.Convert(frame.At(0, 0)).(color.Gray).Y; got != want[i] {
+			t.Errorf("frame %d: got shade %d, want %d", i, got, want[i])
+		}
+	}
+}
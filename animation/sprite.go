@@ -0,0 +1,134 @@
+package animation
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// spriteExt names a sprite sheet descriptor such as walk.sprite.toml
+const spriteExt = ".sprite.toml"
+
+// defaultSpriteFPS is used when a descriptor leaves out fps
+const defaultSpriteFPS = 10
+
+// SpriteSheet describes how a sheet image is cut into frames
+//
+//	image = "walk.png"
+//	frame_width = 64
+//	frame_height = 64
+//	frames = 6     # optional, every cell by default
+//	fps = 12
+//	vertical = true # read down the columns, along the rows by default
+//	plays = 0      # 0 repeats forever
+type SpriteSheet struct {
+	Image       string  `toml:"image"`
+	FrameWidth  int     `toml:"frame_width"`
+	FrameHeight int     `toml:"frame_height"`
+	Frames      int     `toml:"frames"`
+	FPS         float64 `toml:"fps"`
+	Vertical    bool    `toml:"vertical"`
+	Plays       int     `toml:"plays"`
+}
+
+// decodeSpriteSheet reads the descriptor called name, the sheet image is
+// found relative to it
+func decodeSpriteSheet(fsys fs.FS, name string) (*Animation, error) {
+	sheet, err := readSpriteSheet(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fsys.Open(sheet.imagePath(name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", sheet.Image, err)
+	}
+	return sheet.Split(img)
+}
+
+// readSpriteSheet decodes the descriptor called name
+func readSpriteSheet(fsys fs.FS, name string) (SpriteSheet, error) {
+	var sheet SpriteSheet
+	src, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return sheet, err
+	}
+
+	md, err := toml.Decode(string(src), &sheet)
+	if err != nil {
+		return sheet, err
+	}
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return sheet, fmt.Errorf("unknown setting %q", keys[0].String())
+	}
+	if sheet.Image == "" {
+		return sheet, errors.New("sprite sheet has no image")
+	}
+	return sheet, nil
+}
+
+// imagePath is the sheet image of the descriptor called name
+func (s SpriteSheet) imagePath(name string) string {
+	return path.Join(path.Dir(name), s.Image)
+}
+
+// Split cuts img into frames
+func (s SpriteSheet) Split(img image.Image) (*Animation, error) {
+	if s.FrameWidth <= 0 || s.FrameHeight <= 0 {
+		return nil, errors.New("sprite sheet needs frame_width and frame_height")
+	}
+	if s.FPS < 0 {
+		return nil, fmt.Errorf("fps must not be negative, got %v", s.FPS)
+	}
+
+	bounds := img.Bounds()
+	cols := bounds.Dx() / s.FrameWidth
+	rows := bounds.Dy() / s.FrameHeight
+	count := cols * rows
+	if count == 0 {
+		return nil, fmt.Errorf("%dx%d frames do not fit in a %dx%d sheet", s.FrameWidth, s.FrameHeight, bounds.Dx(), bounds.Dy())
+	}
+	if s.Frames > count {
+		return nil, fmt.Errorf("sheet holds %d frames, descriptor wants %d", count, s.Frames)
+	}
+	if s.Frames > 0 {
+		count = s.Frames
+	}
+
+	fps := s.FPS
+	if fps == 0 {
+		fps = defaultSpriteFPS
+	}
+	delay := time.Duration(float64(time.Second) / fps)
+
+	a := &Animation{Plays: s.Plays}
+	for i := range count {
+		col, row := i%cols, i/cols
+		if s.Vertical {
+			col, row = i/rows, i%rows
+		}
+
+		// copy each cell to its own image so frames start at 0,0
+		cell := image.Rect(0, 0, s.FrameWidth, s.FrameHeight).
+			Add(bounds.Min).
+			Add(image.Pt(col*s.FrameWidth, row*s.FrameHeight))
+		frame := image.NewRGBA(image.Rect(0, 0, s.FrameWidth, s.FrameHeight))
+		draw.Draw(frame, frame.Bounds(), img, cell.Min, draw.Src)
+
+		a.Frames = append(a.Frames, frame)
+		a.Delays = append(a.Delays, delay)
+	}
+	return a, nil
+}
@@ -0,0 +1,87 @@
+package animation
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testSheet is a 3x2 sheet of 1x1 cells offset to start at 5,5, each
+// pixel's red is 10*column + row
+func testSheet() image.Image {
+	img := image.NewRGBA(image.Rect(5, 5, 8, 7))
+	for y := range 2 {
+		for x := range 3 {
+			img.Set(5+x, 5+y, color.RGBA{R: uint8(10*x + y), A: 255})
+		}
+	}
+	return img
+}
+
+func TestSpriteSheetSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet SpriteSheet
+		want  []uint8
+	}{
+		{"rows", SpriteSheet{FrameWidth: 1, FrameHeight: 1}, []uint8{0, 10, 20, 1, 11, 21}},
+		{"columns", SpriteSheet{FrameWidth: 1, FrameHeight: 1, Vertical: true}, []uint8{0, 1, 10, 11, 20, 21}},
+		{"first frames", SpriteSheet{FrameWidth: 1, FrameHeight: 1, Frames: 4}, []uint8{0, 10, 20, 1}},
+		{"partial cells dropped", SpriteSheet{FrameWidth: 2, FrameHeight: 1}, []uint8{0, 1}},
+	}
+	for _, tt := range tests {
+		a, err := tt.sheet.Split(testSheet())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []uint8
+		for _, frame := range a.Frames {
+			if frame.Bounds().Min != (image.Point{}) {
+				t.Errorf("%s: frame starts at %v, want 0,0", tt.name, frame.Bounds().Min)
+			}
+			got = append(got, color.RGBAModel.Convert(frame.At(0, 0)).(color.RGBA).R)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got cells %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSpriteSheetSplitDelay(t *testing.T) {
+	tests := []struct {
+		fps  float64
+		want time.Duration
+	}{
+		{0, time.Second / defaultSpriteFPS},
+		{4, 250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		a, err := SpriteSheet{FrameWidth: 1, FrameHeight: 1, FPS: tt.fps}.Split(testSheet())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Delays[0] != tt.want {
+			t.Errorf("fps %v: got delay %v, want %v", tt.fps, a.Delays[0], tt.want)
+		}
+	}
+}
+
+func TestSpriteSheetSplitErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet SpriteSheet
+	}{
+		{"no frame size", SpriteSheet{}},
+		{"negative fps", SpriteSheet{FrameWidth: 1, FrameHeight: 1, FPS: -1}},
+		{"cell larger than sheet", SpriteSheet{FrameWidth: 4, FrameHeight: 1}},
+		{"too many frames", SpriteSheet{FrameWidth: 1, FrameHeight: 1, Frames: 7}},
+	}
+	for _, tt := range tests {
+		if _, err := tt.sheet.Split(testSheet()); err == nil {
+			t.Errorf("%s: got no error", tt.name)
+		}
+	}
+}
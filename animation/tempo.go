@@ -0,0 +1,91 @@
+package animation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"temp.com/go-clock/clock"
+)
+
+// ParseTempo reads how long one loop of a clock synced animation takes:
+// "second", "minute", "hour", a beat rate such as "120bpm" or a duration
+// such as "2s". An empty tempo is 0, the animation runs on its own timer.
+func ParseTempo(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return 0, nil
+	case "second":
+		return time.Second, nil
+	case "minute":
+		return time.Minute, nil
+	case "hour":
+		return time.Hour, nil
+	}
+
+	if bpm, ok := strings.CutSuffix(s, "bpm"); ok {
+		beats, err := strconv.ParseFloat(strings.TrimSpace(bpm), 64)
+		if err != nil || beats <= 0 {
+			return 0, fmt.Errorf("invalid tempo %q, want a positive beat rate such as 120bpm", s)
+		}
+		return time.Duration(float64(time.Minute) / beats), nil
+	}
+
+	period, err := time.ParseDuration(s)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid tempo %q, want second, minute, hour, a beat rate such as 120bpm or a duration such as 2s", s)
+	}
+	return period, nil
+}
+
+// SetTempo locks playback to the clock, playing one loop every period.
+// The frame comes from the local wall time given to Update so the
+// animation stays in phase across restarts. It stops the player's own
+// timer, a period of 0 goes back to it on the next Start.
+func (p *Player) SetTempo(period time.Duration) {
+	p.Stop()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tempo = period
+	p.offsets = make([]time.Duration, len(p.delays))
+	p.loop = 0
+	for i, delay := range p.delays {
+		p.offsets[i] = p.loop
+		p.loop += delay
+	}
+}
+
+// Update shows the frame for t when a tempo is set, register the player
+// with clock.Scheduler.RegisterFrame so it moves smoothly
+func (p *Player) Update(t *clock.TickData) {
+	p.mu.Lock()
+	if p.tempo <= 0 || p.paused || len(p.frames) < 2 {
+		p.mu.Unlock()
+		return
+	}
+
+	// how far through the loop the local wall time is, spread over the
+	// frame delays, so an hour loop restarts on the hour in every zone
+	into := wallNanos(t.Time) % int64(p.tempo)
+	if into < 0 {
+		into += int64(p.tempo) // before 1970
+	}
+	phase := float64(into) / float64(p.tempo)
+	at := time.Duration(phase * float64(p.loop))
+	frame := sort.Search(len(p.offsets), func(i int) bool { return p.offsets[i] > at }) - 1
+
+	changed := frame != p.frame
+	p.frame = frame
+	p.mu.Unlock()
+
+	// Update runs on the fyne main goroutine already
+	if changed {
+		p.Image.Image = p.frames[frame]
+		p.Image.Refresh()
+	}
+}
@@ -0,0 +1,90 @@
+package animation
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"temp.com/go-clock/clock"
+)
+
+func TestParseTempo(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, true},
+		{"second", time.Second, true},
+		{" Minute ", time.Minute, true},
+		{"hour", time.Hour, true},
+		{"120bpm", 500 * time.Millisecond, true},
+		{"2s", 2 * time.Second, true},
+		{"0bpm", 0, false},
+		{"-1s", 0, false},
+		{"fast", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseTempo(tt.in)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("%q: got %v, %v, want %v ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPlayerTempo(t *testing.T) {
+	test.NewTempApp(t)
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+
+	tests := []struct {
+		name  string
+		tempo time.Duration
+		at    time.Time
+		want  int
+	}{
+		{"second, start", time.Second, time.Date(2024, 10, 18, 9, 0, 0, 0, time.UTC), 0},
+		{"second, quarter", time.Second, time.Date(2024, 10, 18, 9, 0, 0, 250e6, time.UTC), 1},
+		{"second, last", time.Second, time.Date(2024, 10, 18, 9, 0, 0, 999e6, time.UTC), 3},
+		{"hour, on the local hour", time.Hour, time.Date(2024, 10, 18, 9, 0, 0, 0, kolkata), 0},
+		{"hour, half past local", time.Hour, time.Date(2024, 10, 18, 9, 30, 0, 0, kolkata), 2},
+		{"minute, 45s local", time.Minute, time.Date(2024, 10, 18, 9, 7, 45, 0, kolkata), 3},
+		{"before 1970", time.Second, time.Date(1969, 12, 31, 23, 59, 59, 500e6, time.UTC), 2},
+	}
+	for _, tt := range tests {
+		// four frames of equal length, a quarter of the loop each
+		p := NewPlayer(testAnimation(0, 50*time.Millisecond, 50*time.Millisecond, 50*time.Millisecond, 50*time.Millisecond))
+		p.SetTempo(tt.tempo)
+		p.frame = -1 // so the first Update always shows its frame
+
+		p.Update(clock.NewTickData(clock.NewFixedClock(tt.at), tt.at.Location()))
+		if p.frame != tt.want {
+			t.Errorf("%s: got frame %d, want %d", tt.name, p.frame, tt.want)
+		}
+		if p.Image.Image != p.frames[tt.want] {
+			t.Errorf("%s: frame %d not shown", tt.name, tt.want)
+		}
+	}
+}
+
+func TestPlayerTempoPaused(t *testing.T) {
+	test.NewTempApp(t)
+	source := clock.NewFixedClock(time.Date(2024, 10, 18, 9, 0, 0, 0, time.UTC))
+	tick := clock.NewTickData(source, time.UTC)
+
+	p := NewPlayer(testAnimation(0, 50*time.Millisecond, 50*time.Millisecond))
+	p.SetTempo(time.Second)
+	p.Pause()
+
+	source.Advance(600 * time.Millisecond)
+	tick.Update()
+	p.Update(tick)
+	if p.frame != 0 {
+		t.Errorf("paused player moved to frame %d", p.frame)
+	}
+
+	p.Resume()
+	p.Update(tick)
+	if p.frame != 1 {
+		t.Errorf("got frame %d after resume, want 1", p.frame)
+	}
+}
@@ -0,0 +1,39 @@
+package animation
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+
+	"golang.org/x/image/webp"
+)
+
+// errAnimatedWebP is returned for animated WebP, only stills are supported
+var errAnimatedWebP = errors.New("webp: animated WebP is not supported, convert it to a GIF or APNG")
+
+func sniffWebP(header []byte) bool {
+	return len(header) >= 12 && bytes.HasPrefix(header, []byte("RIFF")) && string(header[8:12]) == "WEBP"
+}
+
+// animatedWebP reports whether src has a VP8X header with the animation flag
+func animatedWebP(src []byte) bool {
+	return len(src) >= 21 && string(src[12:16]) == "VP8X" && src[20]&0x02 != 0
+}
+
+// decodeWebP loads a still WebP. golang.org/x/image/webp does not decode
+// animated WebP, those are rejected with errAnimatedWebP.
+func decodeWebP(fsys fs.FS, name string) (*Animation, error) {
+	src, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if animatedWebP(src) {
+		return nil, errAnimatedWebP
+	}
+
+	img, err := webp.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	return Still(img), nil
+}
@@ -0,0 +1,16 @@
+package animation
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDecodeWebPAnimated(t *testing.T) {
+	// RIFF header and a VP8X chunk with only the animation flag set
+	src := []byte("RIFF\x1a\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+	fsys := fstest.MapFS{"a.webp": {Data: src}}
+	if _, err := decodeWebP(fsys, "a.webp"); !errors.Is(err, errAnimatedWebP) {
+		t.Errorf("got %v, want %v", err, errAnimatedWebP)
+	}
+}
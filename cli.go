@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"temp.com/go-clock/animation"
+	"temp.com/go-clock/clock"
+	"temp.com/go-clock/config"
+)
+
+// options holds the command line, flags that are not given leave the
+// config file untouched
+type options struct {
+	configPath string
+	faces      []string
+	zones      []string
+	mode24h    *bool
+	fullscreen bool
+	lowPower   bool
+	noGIF      bool
+	gifPath    string
+	tempo      string
+	width      int
+	height     int
+}
+
+func parseFlags(args []string, output io.Writer) (*options, error) {
+	o := &options{}
+	fs := flag.NewFlagSet("go-clock", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-clock [flags]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	var faces, zones, size string
+	var mode24h bool
+
+	fs.StringVar(&o.configPath, "config", "", "config `file` (.toml or .yaml), defaults to go-clock.toml/.yaml if present")
+	fs.StringVar(&faces, "face", "", "comma separated `faces` to show, from "+strings.Join(clock.FaceNames(), ", "))
+	fs.StringVar(&zones, "tz", "", "comma separated IANA `zones`, one per face or one for all faces")
+	fs.BoolVar(&mode24h, "24h", true, "show 24 hour time, use --24h=false for 12 hour")
+	fs.BoolVar(&o.fullscreen, "fullscreen", false, "start full screen")
+	fs.BoolVar(&o.lowPower, "low-power", false, "turn off segment afterglow animations")
+	fs.BoolVar(&o.noGIF, "no-gif", false, "hide the animation panel")
+	fs.StringVar(&o.gifPath, "gif", "", "animation `path` to show instead of Dat Boi: GIF, APNG, still WebP, *.sprite.toml sprite sheet or a directory of numbered PNGs")
+	fs.StringVar(&o.tempo, "tempo", "", "lock the animation to the clock, one loop per `tempo`: second, minute, hour, e.g. 120bpm or 2s")
+	fs.StringVar(&size, "size", "", "window size as `WxH`, e.g. 1024x768")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "24h" {
+			o.mode24h = &mode24h
+		}
+	})
+
+	o.faces = splitList(faces)
+	for _, face := range o.faces {
+		if !slices.Contains(clock.FaceNames(), face) {
+			return nil, fmt.Errorf("--face: unknown face %q, want one of %s", face, strings.Join(clock.FaceNames(), ", "))
+		}
+	}
+
+	o.zones = splitList(zones)
+	for _, zone := range o.zones {
+		if _, err := clock.LoadZone(zone); err != nil {
+			return nil, fmt.Errorf("--tz: %w", err)
+		}
+	}
+
+	if _, err := animation.ParseTempo(o.tempo); err != nil {
+		return nil, fmt.Errorf("--tempo: %w", err)
+	}
+
+	if size != "" {
+		var err error
+		if o.width, o.height, err = parseSize(size); err != nil {
+			return nil, fmt.Errorf("--size: %w", err)
+		}
+	}
+
+	return o, nil
+}
+
+// loadConfig reads the config named on the command line, or the default one
+func (o *options) loadConfig() (*config.Config, error) {
+	if o.configPath != "" {
+		return config.Load(o.configPath)
+	}
+	return config.LoadDefault()
+}
+
+// apply overrides the config with the flags that were given
+func (o *options) apply(cfg *config.Config) error {
+	if len(o.faces) > 0 {
+		faces := make([]config.FaceConfig, len(o.faces))
+		for i, face := range o.faces {
+			faces[i] = config.FaceConfig{Type: face, Sweep: face == clock.FaceAnalog}
+		}
+		cfg.Faces = faces
+	}
+
+	switch {
+	case len(o.zones) == 1:
+		for i := range cfg.Faces {
+			cfg.Faces[i].Zone = o.zones[0]
+		}
+	case len(o.zones) > 1:
+		if len(o.zones) != len(cfg.Faces) {
+			return fmt.Errorf("--tz: got %d zones for %d faces", len(o.zones), len(cfg.Faces))
+		}
+		for i := range cfg.Faces {
+			cfg.Faces[i].Zone = o.zones[i]
+		}
+	}
+
+	if o.mode24h != nil {
+		cfg.Mode24h = *o.mode24h
+		for i := range cfg.Faces {
+			cfg.Faces[i].Mode24h = nil
+		}
+	}
+
+	if o.lowPower {
+		cfg.LowPower = true
+	}
+
+	if o.gifPath != "" {
+		cfg.Animation.Path = o.gifPath
+		cfg.Animation.Playlist = ""
+	}
+	if o.tempo != "" {
+		cfg.Animation.Tempo = o.tempo
+	}
+
+	if o.width > 0 {
+		cfg.Window.Width, cfg.Window.Height = o.width, o.height
+	}
+	return nil
+}
+
+// parseSize reads a window size such as 800x600
+func parseSize(s string) (int, int, error) {
+	w, h, ok := strings.Cut(strings.ToLower(s), "x")
+	width, werr := strconv.Atoi(w)
+	height, herr := strconv.Atoi(h)
+	if !ok || werr != nil || herr != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid size %q, want WxH such as 800x600", s)
+	}
+	return width, height, nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
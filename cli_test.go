@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"temp.com/go-clock/config"
+)
+
+func TestParseFlagsErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--face", "analog,sundial"}, `--face: unknown face "sundial"`},
+		{[]string{"--tz", "Europe/London,Mars/Olympus"}, "--tz:"},
+		{[]string{"--size", "800x600abc"}, "--size:"},
+		{[]string{"--size", "800x600x9"}, "--size:"},
+		{[]string{"--size", "800"}, "--size:"},
+		{[]string{"--size", "0x600"}, "--size:"},
+		{[]string{"--size", "x600"}, "--size:"},
+		{[]string{"--size", "800x-1"}, "--size:"},
+		{[]string{"--tempo", "fast"}, "--tempo:"},
+		{[]string{"--tempo", "0bpm"}, "--tempo:"},
+		{[]string{"extra"}, `unexpected argument "extra"`},
+		{[]string{"--unknown"}, "not defined"},
+	}
+	for _, tt := range tests {
+		_, err := parseFlags(tt.args, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: got %v, want an error containing %q", tt.args, err, tt.want)
+		}
+	}
+
+	if _, err := parseFlags([]string{"--help"}, io.Discard); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("--help: got %v, want flag.ErrHelp", err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	o, err := parseFlags([]string{
+		"--face", "analog, digital", "--tz", "Asia/Tokyo", "--size", "1024X768", "--24h=false", "--tempo", "120bpm",
+	}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.faces) != 2 || o.faces[1] != "digital" {
+		t.Errorf("faces: got %q", o.faces)
+	}
+	if o.width != 1024 || o.height != 768 {
+		t.Errorf("size: got %dx%d, want 1024x768", o.width, o.height)
+	}
+	if o.mode24h == nil || *o.mode24h {
+		t.Errorf("24h: got %v, want false", o.mode24h)
+	}
+
+	// flags that are not given leave the config alone
+	o, err = parseFlags(nil, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.mode24h != nil || o.width != 0 || o.faces != nil {
+		t.Errorf("no flags: got %+v", o)
+	}
+}
+
+const testConfig = `mode24h = true
+
+[window]
+width = 640
+height = 480
+
+[animation]
+playlist = "animations"
+every = "15m"
+tempo = "second"
+
+[[faces]]
+type = "digital"
+zone = "Europe/London"
+mode24h = false
+
+[[faces]]
+type = "ring"
+zone = "Europe/Paris"
+`
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, cfg *config.Config)
+	}{
+		{"no flags keep the config", nil, func(t *testing.T, cfg *config.Config) {
+			if len(cfg.Faces) != 2 || cfg.Faces[0].Zone != "Europe/London" || cfg.Window.Width != 640 {
+				t.Errorf("config changed: %+v", cfg)
+			}
+			if cfg.Faces[0].Mode24h == nil || *cfg.Faces[0].Mode24h {
+				t.Error("face mode24h lost")
+			}
+		}},
+		{"one zone for every face", []string{"--tz", "Asia/Tokyo"}, func(t *testing.T, cfg *config.Config) {
+			for i, face := range cfg.Faces {
+				if face.Zone != "Asia/Tokyo" {
+					t.Errorf("face %d: got zone %q", i, face.Zone)
+				}
+			}
+		}},
+		{"a zone per face", []string{"--tz", "Asia/Tokyo,UTC"}, func(t *testing.T, cfg *config.Config) {
+			if cfg.Faces[0].Zone != "Asia/Tokyo" || cfg.Faces[1].Zone != "UTC" {
+				t.Errorf("got zones %q %q", cfg.Faces[0].Zone, cfg.Faces[1].Zone)
+			}
+		}},
+		{"faces replace the config faces", []string{"--face", "analog"}, func(t *testing.T, cfg *config.Config) {
+			if len(cfg.Faces) != 1 || cfg.Faces[0].Type != "analog" || !cfg.Faces[0].Sweep || cfg.Faces[0].Zone != "" {
+				t.Errorf("got faces %+v", cfg.Faces)
+			}
+		}},
+		{"24h beats the face setting", []string{"--24h"}, func(t *testing.T, cfg *config.Config) {
+			if !cfg.Mode24h || cfg.Faces[0].Mode24h != nil {
+				t.Errorf("got mode24h %v, face %v", cfg.Mode24h, cfg.Faces[0].Mode24h)
+			}
+		}},
+		{"gif replaces the playlist", []string{"--gif", "walk.gif", "--tempo", "minute"}, func(t *testing.T, cfg *config.Config) {
+			if cfg.Animation.Path != "walk.gif" || cfg.Animation.Playlist != "" || cfg.Animation.Tempo != "minute" {
+				t.Errorf("got animation %+v", cfg.Animation)
+			}
+		}},
+		{"size and low power", []string{"--size", "1024x768", "--low-power"}, func(t *testing.T, cfg *config.Config) {
+			if cfg.Window.Width != 1024 || cfg.Window.Height != 768 || !cfg.LowPower {
+				t.Errorf("got window %+v low power %v", cfg.Window, cfg.LowPower)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse("go-clock.toml", []byte(testConfig))
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := parseFlags(tt.args, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := o.apply(cfg); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+
+	cfg, err := config.Parse("go-clock.toml", []byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := parseFlags([]string{"--tz", "UTC,UTC,UTC"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.apply(cfg); err == nil || !strings.Contains(err.Error(), "3 zones for 2 faces") {
+		t.Errorf("zone count mismatch: got %v", err)
+	}
+}
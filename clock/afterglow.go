@@ -0,0 +1,85 @@
+package clock
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// segmentFade is the running afterglow of segment i of a digit
+type segmentFade struct {
+	*fyne.Animation
+	segment int
+}
+
+// SetAfterglow makes segments that switch off fade from onColor to
+// offColor over d, like the decay of a VFD or LED. 0 switches them off
+// at once. Fades already running are finished straight away.
+func (ssd *SevenSegmentDisplay) SetAfterglow(d time.Duration) {
+	ssd.stopFades()
+	ssd.afterglow = max(d, 0)
+}
+
+// SetLowPower skips the afterglow animation while on, keeping the
+// duration for when it is turned off again
+func (ssd *SevenSegmentDisplay) SetLowPower(on bool) {
+	if on {
+		ssd.stopFades()
+	}
+	ssd.lowPower = on
+}
+
+func (ssd *SevenSegmentDisplay) glowing() bool {
+	return ssd.afterglow > 0 && !ssd.lowPower
+}
+
+// fadeOut animates segment i of a digit to offColor, the fill from
+// onColor and the outline from strokeColor
+func (ssd *SevenSegmentDisplay) fadeOut(obj fyne.CanvasObject, i int) {
+	ssd.stopFade(obj)
+	fade := fyne.NewAnimation(ssd.afterglow, func(done float32) {
+		ssd.paintFade(obj, i, done)
+		obj.Refresh()
+	})
+	fade.Curve = fyne.AnimationEaseOut
+
+	if ssd.fading == nil {
+		ssd.fading = map[fyne.CanvasObject]segmentFade{}
+	}
+	ssd.fading[obj] = segmentFade{Animation: fade, segment: i}
+	fade.Start()
+}
+
+// paintFade paints segment i done of the way from lit to off
+func (ssd *SevenSegmentDisplay) paintFade(obj fyne.CanvasObject, i int, done float32) {
+	ssd.paintColors(obj, i, fadeColor(ssd.onColor, ssd.offColor, done), fadeColor(ssd.strokeColor, ssd.offColor, done))
+}
+
+// fadeColor is the colour done of the way from one colour to another
+func fadeColor(from, to color.Color, done float32) color.Color {
+	r1, g1, b1, a1 := from.RGBA()
+	r2, g2, b2, a2 := to.RGBA()
+	mix := func(a, b uint32) uint8 {
+		return uint8((float32(a) + (float32(b)-float32(a))*done) / 0x101)
+	}
+	return color.RGBA{R: mix(r1, r2), G: mix(g1, g2), B: mix(b1, b2), A: mix(a1, a2)}
+}
+
+// stopFade halts the fade of a segment, leaving it for the caller to paint
+func (ssd *SevenSegmentDisplay) stopFade(obj fyne.CanvasObject) {
+	if fade, ok := ssd.fading[obj]; ok {
+		fade.Stop()
+		delete(ssd.fading, obj)
+	}
+}
+
+// stopFades jumps every fading segment to offColor
+func (ssd *SevenSegmentDisplay) stopFades() {
+	for obj, fade := range ssd.fading {
+		fade.Stop()
+		ssd.paintSegment(obj, fade.segment, false)
+		obj.Refresh()
+	}
+	clear(ssd.fading)
+}
@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFadeColor(t *testing.T) {
+	from := color.RGBA{R: 200, G: 100, A: 255}
+	to := color.RGBA{B: 100, A: 255}
+	tests := []struct {
+		done float32
+		want color.RGBA
+	}{
+		{0, from},
+		{0.5, color.RGBA{R: 100, G: 50, B: 50, A: 255}},
+		{1, to},
+	}
+	for _, tt := range tests {
+		if got := fadeColor(from, to, tt.done); got != tt.want {
+			t.Errorf("%v of the way: got %v, want %v", tt.done, got, tt.want)
+		}
+	}
+}
+
+// TestAfterglowStroke checks a fading segment's outline starts from the
+// stroke colour it had while lit rather than jumping to the fill colour
+func TestAfterglowStroke(t *testing.T) {
+	test.NewTempApp(t)
+
+	ssd := NewSevenSegmentDisplay(testOn, testOff, testStroke)
+	ssd.SetAfterglow(time.Second)
+	c, err := ssd.DrawDigit(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	segment := c.Objects[0].(*canvas.Rectangle)
+
+	tests := []struct {
+		done         float32
+		fill, stroke color.Color
+	}{
+		{0, fadeColor(testOn, testOff, 0), fadeColor(testStroke, testOff, 0)},
+		{1, fadeColor(testOn, testOff, 1), fadeColor(testStroke, testOff, 1)},
+	}
+	for _, tt := range tests {
+		ssd.paintFade(segment, 0, tt.done)
+		if segment.FillColor != tt.fill || segment.StrokeColor != tt.stroke {
+			t.Errorf("%v of the way: got fill %v stroke %v, want %v %v",
+				tt.done, segment.FillColor, segment.StrokeColor, tt.fill, tt.stroke)
+		}
+	}
+	if start := fadeColor(testStroke, testOff, 0); start != color.RGBAModel.Convert(testStroke) {
+		t.Errorf("stroke starts at %v, want %v", start, testStroke)
+	}
+
+	// the temp app runs animations to the end at once
+	if err := ssd.SetDigit(c, 8, 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := color.RGBAModel.Convert(testOff); segment.StrokeColor != want {
+		t.Errorf("faded stroke: got %v, want %v", segment.StrokeColor, want)
+	}
+}
@@ -0,0 +1,303 @@
+package clock
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Sixteen segment display segment assignments, a fourteen segment display
+// joins a1 and a2 into a, and d1 and d2 into d:
+//
+//   -a1- -a2-
+//  |\   |   /|
+//  f h  i  j b
+//  |   \|/   |
+//   -g1- -g2-
+//  |   /|\   |
+//  e m  l  k c
+//  |/   |   \|
+//   -d1- -d2-
+
+// Segment bits of an alphanumeric glyph
+const (
+	segA1 uint16 = 1 << iota
+	segA2
+	segB
+	segC
+	segD1
+	segD2
+	segE
+	segF
+	segG1
+	segG2
+	segH
+	segI
+	segJ
+	segK
+	segL
+	segM
+)
+
+var segmentBits = map[string]uint16{
+	"a1": segA1, "a2": segA2, "b": segB, "c": segC, "d1": segD1, "d2": segD2,
+	"e": segE, "f": segF, "g1": segG1, "g2": segG2, "h": segH, "i": segI,
+	"j": segJ, "k": segK, "l": segL, "m": segM,
+}
+
+// glyphSegments lists the lit segments of every printable ASCII character,
+// lower case letters are shown as upper case
+var glyphSegments = map[rune]string{
+	' ':  "",
+	'!':  "i",
+	'"':  "f i",
+	'#':  "b c d1 d2 g1 g2 i l",
+	'$':  "a1 a2 f g1 g2 c d1 d2 i l",
+	'%':  "a1 f j m c d2",
+	'&':  "a1 h j g1 e d1 k",
+	'\'': "j",
+	'(':  "j k",
+	')':  "h m",
+	'*':  "g1 g2 h i j k l m",
+	'+':  "g1 g2 i l",
+	',':  "m",
+	'-':  "g1 g2",
+	'.':  "d1",
+	'/':  "j m",
+	'0':  "a1 a2 b c d1 d2 e f j m",
+	'1':  "b c j",
+	'2':  "a1 a2 b g1 g2 e d1 d2",
+	'3':  "a1 a2 b c d1 d2 g2",
+	'4':  "f g1 g2 b c",
+	'5':  "a1 a2 f g1 g2 c d1 d2",
+	'6':  "a1 a2 f e d1 d2 c g1 g2",
+	'7':  "a1 a2 b c",
+	'8':  "a1 a2 b c d1 d2 e f g1 g2",
+	'9':  "a1 a2 b c d1 d2 f g1 g2",
+	':':  "i l",
+	';':  "i m",
+	'<':  "j k",
+	'=':  "g1 g2 d1 d2",
+	'>':  "h m",
+	'?':  "a1 a2 b g2 l",
+	'@':  "a1 a2 b e f d1 d2 i g2",
+	'A':  "a1 a2 b c e f g1 g2",
+	'B':  "a1 a2 b c d1 d2 g2 i l",
+	'C':  "a1 a2 f e d1 d2",
+	'D':  "a1 a2 b c d1 d2 i l",
+	'E':  "a1 a2 f e d1 d2 g1",
+	'F':  "a1 a2 f e g1",
+	'G':  "a1 a2 f e d1 d2 c g2",
+	'H':  "f e b c g1 g2",
+	'I':  "a1 a2 i l d1 d2",
+	'J':  "b c d1 d2 e",
+	'K':  "f e g1 j k",
+	'L':  "f e d1 d2",
+	'M':  "f e b c h j",
+	'N':  "f e b c h k",
+	'O':  "a1 a2 b c d1 d2 e f",
+	'P':  "a1 a2 b f e g1 g2",
+	'Q':  "a1 a2 b c d1 d2 e f k",
+	'R':  "a1 a2 b f e g1 g2 k",
+	'S':  "a1 a2 f g1 g2 c d1 d2",
+	'T':  "a1 a2 i l",
+	'U':  "f e d1 d2 c b",
+	'V':  "f e m j",
+	'W':  "f e b c m k",
+	'X':  "h j m k",
+	'Y':  "h j l",
+	'Z':  "a1 a2 j m d1 d2",
+	'[':  "a1 f e d1",
+	'\\': "h k",
+	']':  "a2 b c d2",
+	'^':  "m k",
+	'_':  "d1 d2",
+	'`':  "h",
+	'{':  "a2 i l d2 g1",
+	'|':  "i l",
+	'}':  "a1 i l d1 g2",
+	'~':  "m g1 g2 j",
+}
+
+var alphaGlyphs = func() map[rune]uint16 {
+	glyphs := make(map[rune]uint16, len(glyphSegments))
+	for r, names := range glyphSegments {
+		var bits uint16
+		for _, name := range strings.Fields(names) {
+			bits |= segmentBits[name]
+		}
+		glyphs[r] = bits
+	}
+	return glyphs
+}()
+
+// Glyph is the segment bits of r, ok is false when r has no glyph
+func Glyph(r rune) (uint16, bool) {
+	bits, ok := alphaGlyphs[unicode.ToUpper(r)]
+	return bits, ok
+}
+
+// alphaSegment is one segment at design size, straight segments are
+// rectangles and diagonals are lines
+type alphaSegment struct {
+	bits     uint16 // lit when the glyph has any of these bits
+	rect     SegmentRect
+	diagonal bool
+	from, to fyne.Position
+}
+
+const (
+	alphaDesignWidth  = 70
+	alphaDesignHeight = 110
+	alphaDiagonal     = 6 // stroke width of diagonal segments at design size
+	alphaMinScale     = 0.25
+)
+
+func rectSegment(bits uint16, x, y, w, h float32) alphaSegment {
+	return alphaSegment{bits: bits, rect: SegmentRect{Position: fyne.NewPos(x, y), Size: fyne.NewSize(w, h)}}
+}
+
+func lineSegment(bits uint16, x1, y1, x2, y2 float32) alphaSegment {
+	return alphaSegment{bits: bits, diagonal: true, from: fyne.NewPos(x1, y1), to: fyne.NewPos(x2, y2)}
+}
+
+// alphaSegmentShapes is the geometry of a 14 or 16 segment character on
+// the same 70x110 grid as the seven segment digits
+func alphaSegmentShapes(segments int) []alphaSegment {
+	shapes := []alphaSegment{
+		rectSegment(segB, 60, 10, 10, 40),
+		rectSegment(segC, 60, 60, 10, 40),
+		rectSegment(segE, 0, 60, 10, 40),
+		rectSegment(segF, 0, 10, 10, 40),
+		rectSegment(segG1, 10, 50, 24, 10),
+		rectSegment(segG2, 36, 50, 24, 10),
+		rectSegment(segI, 30, 10, 10, 40),
+		rectSegment(segL, 30, 60, 10, 40),
+		lineSegment(segH, 13, 13, 27, 47),
+		lineSegment(segJ, 57, 13, 43, 47),
+		lineSegment(segM, 27, 63, 13, 97),
+		lineSegment(segK, 43, 63, 57, 97),
+	}
+
+	if segments == 14 {
+		return append(shapes,
+			rectSegment(segA1|segA2, 10, 0, 50, 10),
+			rectSegment(segD1|segD2, 10, 100, 50, 10),
+		)
+	}
+	return append(shapes,
+		rectSegment(segA1, 10, 0, 24, 10),
+		rectSegment(segA2, 36, 0, 24, 10),
+		rectSegment(segD1, 10, 100, 24, 10),
+		rectSegment(segD2, 36, 100, 24, 10),
+	)
+}
+
+// AlphanumericDisplay is a row of 14 or 16 segment characters. Like the
+// digital clock it is drawn at a design size and scaled to fit.
+type AlphanumericDisplay struct {
+	widget.BaseWidget
+	charRow
+	shapes      []alphaSegment
+	shown       []uint16
+	onColor     color.Color
+	offColor    color.Color
+	strokeColor color.Color
+}
+
+// NewAlphanumericDisplay makes a display of length characters with 14 or
+// 16 segments each
+func NewAlphanumericDisplay(segments, length int, onColor, offColor, strokeColor color.Color) (*AlphanumericDisplay, error) {
+	if segments != 14 && segments != 16 {
+		return nil, fmt.Errorf("alphanumeric displays have 14 or 16 segments, not %d", segments)
+	}
+
+	a := &AlphanumericDisplay{
+		shapes:      alphaSegmentShapes(segments),
+		onColor:     onColor,
+		offColor:    offColor,
+		strokeColor: strokeColor,
+	}
+	size := fyne.NewSize(alphaDesignWidth, alphaDesignHeight)
+	row, err := newCharRow(a, length, size, alphaDesignWidth/7, alphaMinScale)
+	if err != nil {
+		return nil, err
+	}
+	a.charRow = row
+	a.shown = make([]uint16, length)
+	a.build(a.drawChar)
+
+	a.ExtendBaseWidget(a)
+	return a, nil
+}
+
+func (a *AlphanumericDisplay) drawChar() *fyne.Container {
+	char := container.NewWithoutLayout()
+	for _, shape := range a.shapes {
+		if shape.diagonal {
+			line := canvas.NewLine(a.offColor)
+			line.StrokeWidth = alphaDiagonal
+			line.Position1, line.Position2 = shape.from, shape.to
+			char.Add(line)
+			continue
+		}
+		rect := canvas.NewRectangle(a.offColor)
+		rect.StrokeWidth = 2
+		rect.StrokeColor = a.offColor
+		rect.Resize(shape.rect.Size)
+		rect.Move(shape.rect.Position)
+		char.Add(rect)
+	}
+	return char
+}
+
+// setCell recolours the segments of character i that change
+func (a *AlphanumericDisplay) setCell(i int, r rune) bool {
+	bits, ok := Glyph(r)
+	previous := a.shown[i]
+	a.shown[i] = bits
+	for j, shape := range a.shapes {
+		on := bits&shape.bits != 0
+		if (previous&shape.bits != 0) == on {
+			continue
+		}
+
+		fill, stroke := a.offColor, a.offColor
+		if on {
+			fill, stroke = a.onColor, a.strokeColor
+		}
+		switch obj := a.chars[i].Objects[j].(type) {
+		case *canvas.Rectangle:
+			obj.FillColor, obj.StrokeColor = fill, stroke
+			obj.Refresh()
+		case *canvas.Line:
+			obj.StrokeColor = fill
+			obj.Refresh()
+		}
+	}
+	return ok
+}
+
+func (a *AlphanumericDisplay) layoutCell(char *fyne.Container, scale float32) {
+	for j, shape := range a.shapes {
+		switch obj := char.Objects[j].(type) {
+		case *canvas.Line:
+			obj.StrokeWidth = alphaDiagonal * scale
+			obj.Position1 = fyne.NewPos(shape.from.X*scale, shape.from.Y*scale)
+			obj.Position2 = fyne.NewPos(shape.to.X*scale, shape.to.Y*scale)
+		default:
+			obj.Resize(fyne.NewSize(shape.rect.Size.Width*scale, shape.rect.Size.Height*scale))
+			obj.Move(fyne.NewPos(shape.rect.Position.X*scale, shape.rect.Position.Y*scale))
+		}
+	}
+}
+
+func (a *AlphanumericDisplay) CreateRenderer() fyne.WidgetRenderer {
+	return newCharRowRenderer(a, &a.charRow)
+}
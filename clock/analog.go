@@ -4,128 +4,232 @@ import (
 	"image/color"
 	"math"
 	"strconv"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
 )
 
+// AnalogClock is a widget drawing a dial with hour, minute and second
+// hands. The dial is sized to fit the space it is given, staying round.
 type AnalogClock struct {
+	widget.BaseWidget
 	HourHand    *canvas.Line
 	MinuteHand  *canvas.Line
 	SecondHand  *canvas.Line
-	ClockFace   fyne.CanvasObject
-	cx, cy      int
-	radius      int
+	ClockFace   *canvas.Circle
+	ZoneLabel   *canvas.Text
+	hourMarkers []*canvas.Text
+	tick        *TickData
+	minRadius   int
 	HourAngle   float64
 	MinuteAngle float64
 	SecondAngle float64
+	sweep       bool
 }
 
-func NewAnalogClock(cx, cy, radius int) *AnalogClock {
+// DefaultSweepFPS is the frame rate used to drive a sweeping second hand
+const DefaultSweepFPS = 60
+
+// Dial proportions, given for a dial of analogDesignRadius and scaled
+// with the radius the dial is laid out at
+const (
+	analogDesignRadius     = 80
+	analogHandOffset       = 20  // hands stop short of the rim by this much
+	analogMarkerTextSize   = 18  // hour number size
+	analogMarkerMultiplier = 0.8 // hour numbers sit at this fraction of the radius
+	analogRimWidth         = 5
+	analogLabelGap         = 5
+)
+
+// FrameInterval returns the ticker period for a frame rate
+func FrameInterval(fps int) time.Duration {
+	if fps <= 0 {
+		fps = DefaultSweepFPS
+	}
+	return time.Second / time.Duration(fps)
+}
 
-	time := NewTickData()
+// NewAnalogClock creates an analog clock widget, radius is the smallest
+// dial radius it will shrink to
+func NewAnalogClock(source TimeSource, loc *time.Location, radius int) *AnalogClock {
 
-	offset := 20
+	time := NewTickData(source, loc)
 
-	hourAngle, minuteAngle, secondAngle := getClockHandAngles(time)
+	hourAngle, minuteAngle, secondAngle := getClockHandAngles(time, false)
 
-	clockFace := drawClockFace(cx, cy, radius)
-	hourX, hourY := getClockHandPosition(cx, cy, radius-offset, hourAngle)
-	minuteX, minuteY := getClockHandPosition(cx, cy, radius-offset, minuteAngle)
-	secondX, secondY := getClockHandPosition(cx, cy, radius-offset, secondAngle)
+	clockFace := canvas.NewCircle(color.White)
+	clockFace.StrokeColor = color.Gray{Y: 0x99}
+	clockFace.StrokeWidth = analogRimWidth
 
-	hourHand := drawClockHand(cx, cy, hourX, hourY, color.RGBA{255, 0, 0, 255})
-	minuteHand := drawClockHand(cx, cy, minuteX, minuteY, color.RGBA{0, 255, 0, 255})
-	secondHand := drawClockHand(cx, cy, secondX, secondY, color.RGBA{0, 0, 255, 255})
+	hourMarkers := make([]*canvas.Text, 12)
+	for i := range hourMarkers {
+		hourMarkers[i] = canvas.NewText(strconv.Itoa(i+1), color.Black)
+	}
 
-	return &AnalogClock{
-		HourHand:    hourHand,
-		MinuteHand:  minuteHand,
-		SecondHand:  secondHand,
+	a := &AnalogClock{
+		HourHand:    drawClockHand(color.RGBA{255, 0, 0, 255}),
+		MinuteHand:  drawClockHand(color.RGBA{0, 255, 0, 255}),
+		SecondHand:  drawClockHand(color.RGBA{0, 0, 255, 255}),
 		ClockFace:   clockFace,
-		cx:          cx,
-		cy:          cy,
-		radius:      radius,
+		ZoneLabel:   drawZoneLabel(time.ZoneName()),
+		hourMarkers: hourMarkers,
+		tick:        time,
+		minRadius:   radius,
 		HourAngle:   hourAngle,
 		MinuteAngle: minuteAngle,
 		SecondAngle: secondAngle,
 	}
+	a.ExtendBaseWidget(a)
+	return a
+}
+
+// CanvasObject returns the widget itself
+func (a *AnalogClock) CanvasObject() fyne.CanvasObject {
+	return a
+}
+
+func (a *AnalogClock) Name() string {
+	return FaceAnalog
 }
 
-func getClockHandAngles(time *TickData) (float64, float64, float64) {
-	hourAngle := (float64(time.Hour12) + float64(time.Minute)/60.0) * 30.0
+func (a *AnalogClock) CreateRenderer() fyne.WidgetRenderer {
+	objects := []fyne.CanvasObject{a.ClockFace}
+	for _, marker := range a.hourMarkers {
+		objects = append(objects, marker)
+	}
+	objects = append(objects, a.HourHand, a.MinuteHand, a.SecondHand, a.ZoneLabel)
+
+	return &analogRenderer{clock: a, objects: objects}
+}
+
+// sweep includes the sub-second fraction so hands move continuously
+func getClockHandAngles(time *TickData, sweep bool) (float64, float64, float64) {
+	if sweep {
+		seconds := time.FractionalSecond()
+		minutes := float64(time.Minute) + seconds/60.0
+
+		return (float64(time.dialHour()) + minutes/60.0) * 30.0, minutes * 6, seconds * 6
+	}
+
+	hourAngle := (float64(time.dialHour()) + float64(time.Minute)/60.0) * 30.0
 	minuteAngle := float64(time.Minute) * 6
 	secondAngle := float64(time.Second) * 6
 
 	return hourAngle, minuteAngle, secondAngle
 }
 
-func drawClockFace(cx int, cy int, radius int) fyne.CanvasObject {
+func drawClockHand(handColor color.Color) *canvas.Line {
+	hand := canvas.NewLine(handColor)
+	hand.StrokeWidth = 2
+	return hand
+}
 
-	hourMakerColor := color.Black
-	hour_marker_multiplier := 0.8
+// @return x, y coordinates of the clock hand position
+func getClockHandPosition(cx, cy, radius float32, angle float64) (float32, float32) {
 
-	face := container.NewWithoutLayout()
+	angle_rad := angle * (math.Pi / 180)
 
-	circle := canvas.NewCircle(color.White)
-	circle.StrokeColor = color.Gray{Y: 0x99}
-	circle.StrokeWidth = 5
-	circle.Resize(fyne.NewSize(float32(radius*2), float32(radius*2)))
-	circle.Move(fyne.NewPos(float32(cx-radius), float32(cy-radius)))
+	x := float64(cx) + float64(radius)*math.Sin(angle_rad)
+	y := float64(cy) - float64(radius)*math.Cos(angle_rad)
 
-	face.Add(circle)
+	return float32(x), float32(y)
+}
 
-	// Hour markers
+func placeHand(hand *canvas.Line, cx, cy, length float32, angle float64) {
+	x, y := getClockHandPosition(cx, cy, length, angle)
+	hand.Position1 = fyne.NewPos(cx, cy)
+	hand.Position2 = fyne.NewPos(x, y)
+}
 
-	hourMarkers := container.NewWithoutLayout()
+// SetSweep switches between ticking hands and a smooth sweep. A sweeping
+// clock needs to be updated every frame, see FrameInterval.
+func (a *AnalogClock) SetSweep(sweep bool) {
+	a.sweep = sweep
+}
 
-	for i := 1; i <= 12; i++ {
-		hourMarker := canvas.NewText(strconv.Itoa(i), hourMakerColor)
-		hourMarker.TextSize = 18
-		hourMarker.Refresh()
-		textSize := hourMarker.MinSize()
-		angle := float64(i%12) * (math.Pi / 6)
+func (a *AnalogClock) Sweep() bool {
+	return a.sweep
+}
 
-		x := float64(cx) + float64(radius)*hour_marker_multiplier*float64(math.Sin(angle)) - float64(textSize.Width)/2
-		y := float64(cy) - float64(radius)*hour_marker_multiplier*float64(math.Cos(angle)) - (float64(textSize.Height) / 2) - 6
+func (a *AnalogClock) Update(t *TickData) {
+	a.tick.Sync(t)
+	t = a.tick
 
-		hourMarker.Move(fyne.NewPos(float32(x), float32(y)))
-		hourMarkers.Add(hourMarker)
+	if zone := t.ZoneName(); a.ZoneLabel.Text != zone {
+		a.ZoneLabel.Text = zone
+		a.ZoneLabel.Refresh()
 	}
 
-	return container.NewVBox(face, hourMarkers)
+	a.HourAngle, a.MinuteAngle, a.SecondAngle = getClockHandAngles(t, a.sweep)
+	a.Refresh()
 }
 
-func drawClockHand(cx int, cy int, x int, y int, handColor color.Color) *canvas.Line {
-	hand := canvas.NewLine(handColor)
-	hand.StrokeWidth = 2
-	hand.Position1 = fyne.NewPos(float32(cx), float32(cy))
-	hand.Position2 = fyne.NewPos(float32(x), float32(y))
-	return hand
+type analogRenderer struct {
+	clock   *AnalogClock
+	objects []fyne.CanvasObject
+	cx, cy  float32
+	radius  float32
 }
 
-// @return x, y coordinates of the clock hand position
-func getClockHandPosition(cx int, cy int, radius int, angle float64) (int, int) {
+// Layout fits the largest round dial into size, leaving room for the
+// zone label underneath
+func (r *analogRenderer) Layout(size fyne.Size) {
+	a := r.clock
+	labelHeight := a.ZoneLabel.MinSize().Height
 
-	angle_rad := angle * (math.Pi / 180)
+	r.radius = max(min(size.Width, size.Height-labelHeight-analogLabelGap)/2-analogRimWidth, 1)
+	r.cx = size.Width / 2
+	r.cy = (size.Height - labelHeight - analogLabelGap) / 2
+
+	a.ClockFace.Resize(fyne.NewSize(r.radius*2, r.radius*2))
+	a.ClockFace.Move(fyne.NewPos(r.cx-r.radius, r.cy-r.radius))
+
+	scale := r.radius / analogDesignRadius
+	for i, marker := range a.hourMarkers {
+		marker.TextSize = analogMarkerTextSize * scale
+		textSize := marker.MinSize()
+		angle := float64((i+1)%12) * (math.Pi / 6)
 
-	x := int(float64(cx) + float64(radius)*math.Sin(angle_rad))
-	y := int(float64(cy) - float64(radius)*math.Cos(angle_rad))
+		x := float64(r.cx) + float64(r.radius)*analogMarkerMultiplier*math.Sin(angle) - float64(textSize.Width)/2
+		y := float64(r.cy) - float64(r.radius)*analogMarkerMultiplier*math.Cos(angle) - float64(textSize.Height)/2
 
-	return x, y
+		marker.Resize(textSize)
+		marker.Move(fyne.NewPos(float32(x), float32(y)))
+	}
+
+	a.ZoneLabel.Resize(fyne.NewSize(size.Width, labelHeight))
+	a.ZoneLabel.Move(fyne.NewPos(0, size.Height-labelHeight))
+
+	r.placeHands()
 }
 
-func updateHand(hand *canvas.Line, cx int, cy int, radius int, angle float64) {
-	offset := 20
-	X, Y := getClockHandPosition(cx, cy, radius-offset, angle)
-	hand.Position2 = fyne.NewPos(float32(X), float32(Y))
-	canvas.Refresh(hand)
+func (r *analogRenderer) placeHands() {
+	a := r.clock
+	length := max(r.radius*(1-float32(analogHandOffset)/analogDesignRadius), 0)
+
+	placeHand(a.HourHand, r.cx, r.cy, length, a.HourAngle)
+	placeHand(a.MinuteHand, r.cx, r.cy, length, a.MinuteAngle)
+	placeHand(a.SecondHand, r.cx, r.cy, length, a.SecondAngle)
 }
 
-func (a *AnalogClock) Update(t *TickData) {
-	a.HourAngle, a.MinuteAngle, a.SecondAngle = getClockHandAngles(t)
-	updateHand(a.HourHand, a.cx, a.cy, a.radius, a.HourAngle)
-	updateHand(a.MinuteHand, a.cx, a.cy, a.radius, a.MinuteAngle)
-	updateHand(a.SecondHand, a.cx, a.cy, a.radius, a.SecondAngle)
+func (r *analogRenderer) MinSize() fyne.Size {
+	a := r.clock
+	diameter := float32(a.minRadius+analogRimWidth) * 2
+	return fyne.NewSize(diameter, diameter+analogLabelGap+a.ZoneLabel.MinSize().Height)
 }
+
+// Refresh only moves the hands, the dial is placed by Layout
+func (r *analogRenderer) Refresh() {
+	r.placeHands()
+	for _, hand := range []*canvas.Line{r.clock.HourHand, r.clock.MinuteHand, r.clock.SecondHand} {
+		hand.Refresh()
+	}
+}
+
+func (r *analogRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *analogRenderer) Destroy() {}
@@ -0,0 +1,311 @@
+package clock
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+)
+
+// Palette holds the colours shared by all faces on a board
+type Palette struct {
+	On          color.Color
+	Off         color.Color
+	Stroke      color.Color
+	SecondColor color.Color
+	MinuteColor color.Color
+	HourColor   color.Color
+}
+
+func DefaultPalette() Palette {
+	return Palette{
+		On:          color.RGBA{R: 255, G: 150, B: 50, A: 255},
+		Off:         color.RGBA{R: 50, G: 50, B: 50, A: 255},
+		Stroke:      color.RGBA{R: 200, G: 100, B: 30, A: 255},
+		SecondColor: color.RGBA{R: 0, G: 255, B: 100, A: 255},
+		MinuteColor: color.RGBA{R: 50, G: 150, B: 255, A: 255},
+		HourColor:   color.RGBA{R: 255, G: 80, B: 80, A: 255},
+	}
+}
+
+// Merge returns p with every colour that is set in override replaced
+func (p Palette) Merge(override Palette) Palette {
+	pick := func(base, over color.Color) color.Color {
+		if over != nil {
+			return over
+		}
+		return base
+	}
+	return Palette{
+		On:          pick(p.On, override.On),
+		Off:         pick(p.Off, override.Off),
+		Stroke:      pick(p.Stroke, override.Stroke),
+		SecondColor: pick(p.SecondColor, override.SecondColor),
+		MinuteColor: pick(p.MinuteColor, override.MinuteColor),
+		HourColor:   pick(p.HourColor, override.HourColor),
+	}
+}
+
+// BoardEntry describes one face on a WorldClockBoard. Zero values of the
+// optional fields fall back to the board wide settings.
+type BoardEntry struct {
+	Label    string  // shown above the face, defaults to the zone name
+	Zone     string  // IANA zone name, empty for the process zone
+	Face     string  // registered face name, see FaceNames
+	Sweep    bool    // smooth second hand, analog faces only
+	Size     int     // smallest radius or digit width, 0 for the face default
+	Mode24hr *bool   // overrides the board's 12/24 hour mode
+	Palette  Palette // colours set here replace the board palette
+	Digital  DigitalOptions
+}
+
+// BusinessHours is the local working day used to highlight a zone,
+// Start inclusive and End exclusive, Monday to Friday.
+type BusinessHours struct {
+	Start int
+	End   int
+}
+
+// WorldClockBoard lays out one face per entry in a grid that wraps to
+// as many columns as the window width allows.
+type WorldClockBoard struct {
+	Container      *fyne.Container
+	Hours          BusinessHours
+	HighlightColor color.Color
+	Reference      *time.Location // zone the day offset markers count from, time.Local unless set
+	cells          []*boardCell
+	resync         bool // redraw faces from scratch on the next Update
+}
+
+type boardCell struct {
+	entry      BoardEntry
+	tick       *TickData
+	face       Face
+	sweeping   bool
+	background *canvas.Rectangle
+	title      *canvas.Text
+	dayMarker  *canvas.Text
+}
+
+const (
+	boardTitleTextSize  = 16
+	boardMarkerTextSize = 14
+)
+
+func NewWorldClockBoard(source TimeSource, entries []BoardEntry, palette Palette, mode24hr bool) (*WorldClockBoard, error) {
+	b := &WorldClockBoard{
+		Hours:          BusinessHours{Start: 9, End: 17},
+		HighlightColor: color.NRGBA{R: 0, G: 160, B: 80, A: 60},
+		Reference:      time.Local,
+	}
+
+	objects := []fyne.CanvasObject{}
+	for _, entry := range entries {
+		cell, obj, err := b.newCell(source, entry, palette, mode24hr)
+		if err != nil {
+			return nil, err
+		}
+		b.cells = append(b.cells, cell)
+		objects = append(objects, obj)
+	}
+
+	b.Container = container.New(&boardLayout{}, objects...)
+	b.refreshCells()
+
+	return b, nil
+}
+
+func (b *WorldClockBoard) newCell(source TimeSource, entry BoardEntry, palette Palette, mode24hr bool) (*boardCell, fyne.CanvasObject, error) {
+	loc, err := LoadZone(entry.Zone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tick := NewTickData(source, loc)
+	if entry.Label == "" {
+		entry.Label = tick.ZoneName()
+	}
+
+	if entry.Mode24hr != nil {
+		mode24hr = *entry.Mode24hr
+	}
+
+	face, err := NewFace(entry.Face, FaceOptions{
+		Source:   source,
+		Location: loc,
+		Palette:  palette.Merge(entry.Palette),
+		Mode24hr: mode24hr,
+		Sweep:    entry.Sweep,
+		Size:     entry.Size,
+		Digital:  entry.Digital,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// faces that sweep are moved by UpdateFrame instead of Update
+	sweeping := false
+	if s, ok := face.(interface{ Sweep() bool }); ok {
+		sweeping = s.Sweep()
+	}
+
+	title := canvas.NewText(entry.Label, color.White)
+	title.TextSize = boardTitleTextSize
+	title.TextStyle = fyne.TextStyle{Bold: true}
+
+	dayMarker := canvas.NewText("", color.RGBA{R: 255, G: 200, B: 0, A: 255})
+	dayMarker.TextSize = boardMarkerTextSize
+	dayMarker.TextStyle = fyne.TextStyle{Bold: true}
+
+	background := canvas.NewRectangle(color.Transparent)
+	background.CornerRadius = 8
+
+	header := container.NewHBox(title, dayMarker)
+	obj := container.NewStack(background, container.NewPadded(container.NewBorder(header, nil, nil, nil, face.CanvasObject())))
+
+	cell := &boardCell{
+		entry:      entry,
+		tick:       tick,
+		face:       face,
+		sweeping:   sweeping,
+		background: background,
+		title:      title,
+		dayMarker:  dayMarker,
+	}
+	return cell, obj, nil
+}
+
+// Update moves every face on the board to the instant held by t.
+// Sweeping faces are left to UpdateFrame.
+func (b *WorldClockBoard) Update(t *TickData) {
+	for _, cell := range b.cells {
+		cell.tick.Sync(t)
+		if !cell.sweeping {
+			cell.face.Update(t)
+		}
+		// faces that build up from tick events, like the ring, fill in
+		// what they missed
+		if f, ok := cell.face.(interface{ BackFillArcsContainer() }); ok && b.resync {
+			f.BackFillArcsContainer()
+		}
+	}
+	b.resync = false
+	b.refreshCells()
+}
+
+// Resync makes the next Update redraw every face from the time alone,
+// call it when ticks were missed or the clock jumped
+func (b *WorldClockBoard) Resync() {
+	b.resync = true
+}
+
+// UpdateFrame moves only the sweeping faces, call it every frame
+func (b *WorldClockBoard) UpdateFrame(t *TickData) {
+	for _, cell := range b.cells {
+		if cell.sweeping {
+			cell.face.Update(t)
+		}
+	}
+}
+
+// Sweeping reports whether any face on the board needs frame updates
+func (b *WorldClockBoard) Sweeping() bool {
+	for _, cell := range b.cells {
+		if cell.sweeping {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *WorldClockBoard) refreshCells() {
+	for _, cell := range b.cells {
+		marker := dayOffsetMarker(DayOffset(cell.tick.Time, b.Reference))
+		if cell.dayMarker.Text != marker {
+			cell.dayMarker.Text = marker
+			cell.dayMarker.Refresh()
+		}
+
+		fill := color.Color(color.Transparent)
+		if b.Hours.Contains(cell.tick.Time) {
+			fill = b.HighlightColor
+		}
+		if cell.background.FillColor != fill {
+			cell.background.FillColor = fill
+			cell.background.Refresh()
+		}
+	}
+}
+
+// DayOffset returns how many calendar days t's local date is ahead of
+// the date at the same instant in the reference zone
+func DayOffset(t time.Time, reference *time.Location) int {
+	ly, lm, ld := t.Date()
+	ry, rm, rd := t.In(reference).Date()
+	local := time.Date(ly, lm, ld, 0, 0, 0, 0, time.UTC)
+	ref := time.Date(ry, rm, rd, 0, 0, 0, 0, time.UTC)
+	return int(local.Sub(ref).Hours() / 24)
+}
+
+func dayOffsetMarker(offset int) string {
+	if offset == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+d", offset)
+}
+
+// Contains reports whether t, in its own zone, falls on a weekday
+// between Start and End
+func (h BusinessHours) Contains(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return t.Hour() >= h.Start && t.Hour() < h.End
+}
+
+// boardLayout places cells in equal sized columns, using as many
+// columns as fit the widest cell and wrapping the rest onto new rows
+type boardLayout struct{}
+
+func (l *boardLayout) cellMinSize(objects []fyne.CanvasObject) fyne.Size {
+	cell := fyne.NewSize(0, 0)
+	for _, o := range objects {
+		if o.Visible() {
+			cell = cell.Max(o.MinSize())
+		}
+	}
+	return cell
+}
+
+func (l *boardLayout) columns(width float32, cell fyne.Size, count int) int {
+	if count == 0 {
+		return 1
+	}
+	cols := 1
+	if cell.Width > 0 {
+		cols = int(width / cell.Width)
+	}
+	return max(1, min(cols, count))
+}
+
+func (l *boardLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	cell := l.cellMinSize(objects)
+	cols := l.columns(size.Width, cell, len(objects))
+	rows := int(math.Ceil(float64(len(objects)) / float64(cols)))
+
+	cellSize := fyne.NewSize(size.Width/float32(cols), size.Height/float32(max(rows, 1)))
+	for i, o := range objects {
+		row, col := i/cols, i%cols
+		o.Move(fyne.NewPos(float32(col)*cellSize.Width, float32(row)*cellSize.Height))
+		o.Resize(cellSize)
+	}
+}
+
+// MinSize is a single cell, the board wraps to one column when narrow
+func (l *boardLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.cellMinSize(objects)
+}
@@ -0,0 +1,136 @@
+package clock
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// a missed second leaves a gap in the ring until the board is resynced
+func TestWorldClockBoardResync(t *testing.T) {
+	test.NewTempApp(t)
+
+	source := NewFixedClock(time.Date(2024, 10, 18, 9, 0, 10, 0, time.UTC))
+	board, err := NewWorldClockBoard(source, []BoardEntry{{Face: FaceRing, Zone: "UTC"}}, DefaultPalette(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := board.cells[0].face.(*RingClock)
+	ring.BackFillArcsContainer()
+	tick := NewTickData(source, time.UTC)
+
+	source.Advance(2 * time.Second)
+	tick.Update()
+	board.Update(tick)
+	if got := len(ring.arcAngles[0]); got != 12 {
+		t.Fatalf("without resync got %d second arcs, want the 12 of 0-10 and 12", got)
+	}
+
+	source.Advance(time.Second)
+	tick.Update()
+	board.Resync()
+	board.Update(tick)
+	if got := len(ring.arcAngles[0]); got != 14 {
+		t.Errorf("after resync got %d second arcs, want 14 for 0-13", got)
+	}
+}
+
+func mustZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := LoadZone(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// Kiritimati is UTC+14 and Pago Pago UTC-11, 25 hours apart either side
+// of the date line
+func TestDayOffset(t *testing.T) {
+	kiritimati := mustZone(t, "Pacific/Kiritimati")
+	pagoPago := mustZone(t, "Pacific/Pago_Pago")
+	tests := []struct {
+		at       time.Time
+		loc, ref *time.Location
+		want     int
+	}{
+		{time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC), kiritimati, pagoPago, 1},   // 18th 23:00 and 17th 22:00
+		{time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC), kiritimati, pagoPago, 2}, // 19th 00:30 and 17th 23:30
+		{time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC), pagoPago, kiritimati, -2},
+		{time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC), kiritimati, pagoPago, 1}, // 19th 01:00 and 18th 00:00
+		{time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC), kiritimati, time.UTC, 1},
+		{time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC), pagoPago, pagoPago, 0},
+		// across the new year
+		{time.Date(2026, 12, 31, 11, 0, 0, 0, time.UTC), kiritimati, pagoPago, 1},
+		{time.Date(2026, 12, 31, 10, 30, 0, 0, time.UTC), pagoPago, kiritimati, -2},
+	}
+	for _, tt := range tests {
+		if got := DayOffset(tt.at.In(tt.loc), tt.ref); got != tt.want {
+			t.Errorf("%v in %v from %v: got %d, want %d", tt.at, tt.loc, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestBusinessHoursContains(t *testing.T) {
+	tokyo := mustZone(t, "Asia/Tokyo")
+	hours := BusinessHours{Start: 9, End: 17}
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 10, 16, 8, 59, 59, 0, time.UTC), false}, // Friday
+		{time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 10, 16, 16, 59, 59, 0, time.UTC), true},
+		{time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), false}, // Saturday
+		{time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC), false}, // Sunday
+		{time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), true},   // Monday
+		// Monday morning in Tokyo is still Sunday in UTC
+		{time.Date(2026, 10, 19, 9, 30, 0, 0, tokyo), true},
+		{time.Date(2026, 10, 19, 9, 30, 0, 0, tokyo).UTC(), false},
+	}
+	for _, tt := range tests {
+		if got := hours.Contains(tt.at); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.at.Format("Mon 15:04:05 MST"), got, tt.want)
+		}
+	}
+}
+
+func TestWorldClockBoardReference(t *testing.T) {
+	test.NewTempApp(t)
+
+	// Monday 10:00 in Kiritimati, Sunday 09:00 in Pago Pago
+	source := NewFixedClock(time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC))
+	board, err := NewWorldClockBoard(source, []BoardEntry{
+		{Face: FaceDigital, Zone: "Pacific/Kiritimati"},
+		{Face: FaceDigital, Zone: "Pacific/Pago_Pago"},
+	}, DefaultPalette(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick := NewTickData(source, time.UTC)
+
+	board.Reference = mustZone(t, "Pacific/Pago_Pago")
+	board.Update(tick)
+	markers := []string{board.cells[0].dayMarker.Text, board.cells[1].dayMarker.Text}
+	if want := []string{"+1", ""}; !slices.Equal(markers, want) {
+		t.Errorf("from Pago Pago: got markers %q, want %q", markers, want)
+	}
+
+	board.Reference = mustZone(t, "Pacific/Kiritimati")
+	board.Update(tick)
+	markers = []string{board.cells[0].dayMarker.Text, board.cells[1].dayMarker.Text}
+	if want := []string{"", "-1"}; !slices.Equal(markers, want) {
+		t.Errorf("from Kiritimati: got markers %q, want %q", markers, want)
+	}
+
+	if got := board.cells[0].background.FillColor; got != board.HighlightColor {
+		t.Errorf("Kiritimati on Monday morning: got %v, want highlighted", got)
+	}
+	if got := board.cells[1].background.FillColor; got != color.Transparent {
+		t.Errorf("Pago Pago on Sunday: got %v, want no highlight", got)
+	}
+}
@@ -0,0 +1,147 @@
+package clock
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// charCells draws the characters of a display built on a charRow
+type charCells interface {
+	// setCell shows r in character i and reports whether r has a glyph,
+	// anything without one, including 0, is drawn blank
+	setCell(i int, r rune) bool
+	// layoutCell places the parts of one character at scale times its
+	// design size
+	layoutCell(char *fyne.Container, scale float32)
+}
+
+// charRow is a row of equally sized characters drawn at a design size
+// and scaled to fit, the part AlphanumericDisplay and DotMatrixDisplay
+// have in common
+type charRow struct {
+	Characters *fyne.Container
+	chars      []*fyne.Container
+	text       string
+	cells      charCells
+	charSize   fyne.Size // design size of one character
+	spacing    float32   // design gap between characters
+	minScale   float32
+}
+
+func newCharRow(cells charCells, length int, charSize fyne.Size, spacing, minScale float32) (charRow, error) {
+	if length <= 0 {
+		return charRow{}, fmt.Errorf("display length must be at least 1, got %d", length)
+	}
+	return charRow{
+		Characters: container.NewWithoutLayout(),
+		chars:      make([]*fyne.Container, length),
+		cells:      cells,
+		charSize:   charSize,
+		spacing:    spacing,
+		minScale:   minScale,
+	}, nil
+}
+
+// build fills the row with characters made by newChar
+func (c *charRow) build(newChar func() *fyne.Container) {
+	for i := range c.chars {
+		c.chars[i] = newChar()
+		c.Characters.Add(c.chars[i])
+	}
+}
+
+// Len is how many characters the display holds
+func (c *charRow) Len() int {
+	return len(c.chars)
+}
+
+// Text is what was last drawn
+func (c *charRow) Text() string {
+	return c.text
+}
+
+// DrawText shows text left aligned, blanking the rest of the display.
+// Characters past the end are dropped and characters without a glyph
+// are left blank and reported in the error.
+func (c *charRow) DrawText(text string) error {
+	c.text = text
+
+	var unknown []rune
+	runes := []rune(text)
+	for i := range c.chars {
+		r := rune(0) // past the end of text
+		if i < len(runes) {
+			r = runes[i]
+		}
+		if !c.cells.setCell(i, r) && r != 0 {
+			unknown = append(unknown, r)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("no glyph for %q", string(unknown))
+	}
+	return nil
+}
+
+// design width of every character and the gaps between them
+func (c *charRow) designWidth() float32 {
+	n := float32(len(c.chars))
+	return n*c.charSize.Width + (n-1)*c.spacing
+}
+
+func (c *charRow) designHeight() float32 {
+	return c.charSize.Height
+}
+
+// layout scales the characters to the largest size that fits, centred
+func (c *charRow) layout(size fyne.Size) {
+	width, height := c.designWidth(), c.designHeight()
+
+	scale := max(min(size.Width/width, size.Height/height), 0)
+	x := (size.Width - width*scale) / 2
+	y := (size.Height - height*scale) / 2
+
+	c.Characters.Resize(size)
+	c.Characters.Move(fyne.NewPos(0, 0))
+
+	for _, char := range c.chars {
+		char.Resize(fyne.NewSize(c.charSize.Width*scale, height*scale))
+		char.Move(fyne.NewPos(x, y))
+		c.cells.layoutCell(char, scale)
+
+		x += (c.charSize.Width + c.spacing) * scale
+	}
+}
+
+// charRowRenderer renders the widget a charRow is embedded in
+type charRowRenderer struct {
+	widget  fyne.Widget
+	row     *charRow
+	objects []fyne.CanvasObject
+}
+
+func newCharRowRenderer(w fyne.Widget, row *charRow) *charRowRenderer {
+	return &charRowRenderer{widget: w, row: row, objects: []fyne.CanvasObject{row.Characters}}
+}
+
+func (r *charRowRenderer) Layout(size fyne.Size) {
+	r.row.layout(size)
+}
+
+func (r *charRowRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(r.row.designWidth()*r.row.minScale, r.row.designHeight()*r.row.minScale)
+}
+
+func (r *charRowRenderer) Refresh() {
+	r.Layout(r.widget.Size())
+	r.row.Characters.Refresh()
+}
+
+func (r *charRowRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *charRowRenderer) Destroy() {}
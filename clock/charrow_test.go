@@ -0,0 +1,150 @@
+package clock
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+var (
+	testOn     = color.NRGBA{R: 255, G: 150, B: 50, A: 255}
+	testOff    = color.NRGBA{R: 50, G: 50, B: 50, A: 255}
+	testStroke = color.NRGBA{R: 200, G: 100, B: 30, A: 255}
+)
+
+// litLEDs counts the LEDs of character i showing the on colour
+func litLEDs(m *DotMatrixDisplay, i int) int {
+	lit := 0
+	for _, obj := range m.chars[i].Objects {
+		if obj.(*canvas.Circle).FillColor == color.Color(testOn) {
+			lit++
+		}
+	}
+	return lit
+}
+
+func TestCharRowDrawText(t *testing.T) {
+	test.NewTempApp(t)
+
+	alpha, err := NewAlphanumericDisplay(16, 4, testOn, testOff, testStroke)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix, err := NewDotMatrixDisplay(Font5x7, 4, testOn, testOff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		text    string
+		unknown string // runes reported in the error, empty for none
+		lit     []bool // whether each character has any LED lit
+	}{
+		{"ab", "", []bool{true, true, false, false}},
+		{"1234567", "", []bool{true, true, true, true}},
+		{"aéb", "é", []bool{true, false, true, false}},
+		{"", "", []bool{false, false, false, false}},
+	}
+	for _, tt := range tests {
+		for _, row := range []interface {
+			DrawText(string) error
+			Text() string
+			Len() int
+		}{alpha, matrix} {
+			err := row.DrawText(tt.text)
+			switch {
+			case tt.unknown == "" && err != nil:
+				t.Errorf("%T %q: %v", row, tt.text, err)
+			case tt.unknown != "" && (err == nil || !strings.Contains(err.Error(), tt.unknown)):
+				t.Errorf("%T %q: got %v, want an error naming %q", row, tt.text, err, tt.unknown)
+			}
+			if row.Text() != tt.text || row.Len() != 4 {
+				t.Errorf("%T: got text %q len %d, want %q 4", row, row.Text(), row.Len(), tt.text)
+			}
+		}
+
+		for i, want := range tt.lit {
+			if got := litLEDs(matrix, i) > 0; got != want {
+				t.Errorf("matrix %q: character %d lit %v, want %v", tt.text, i, got, want)
+			}
+			if got := alpha.shown[i] != 0; got != want {
+				t.Errorf("alphanumeric %q: character %d lit %v, want %v", tt.text, i, got, want)
+			}
+		}
+	}
+}
+
+func TestCharRowLength(t *testing.T) {
+	if _, err := NewDotMatrixDisplay(Font5x7, 0, testOn, testOff); err == nil {
+		t.Error("dot matrix: got no error for length 0")
+	}
+	if _, err := NewAlphanumericDisplay(14, 0, testOn, testOff, testStroke); err == nil {
+		t.Error("alphanumeric: got no error for length 0")
+	}
+}
+
+func TestCharRowLayout(t *testing.T) {
+	test.NewTempApp(t)
+
+	matrix, err := NewDotMatrixDisplay(Font5x7, 2, testOn, testOff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2 characters of 50x70 and a gap of 10, at half size and centred
+	matrix.Resize(fyne.NewSize(200, 35))
+
+	if got, want := matrix.chars[0].Position(), fyne.NewPos(72.5, 0); got != want {
+		t.Errorf("first character at %v, want %v", got, want)
+	}
+	if got, want := matrix.chars[1].Position(), fyne.NewPos(102.5, 0); got != want {
+		t.Errorf("second character at %v, want %v", got, want)
+	}
+	if got, want := matrix.chars[1].Size(), fyne.NewSize(25, 35); got != want {
+		t.Errorf("character size %v, want %v", got, want)
+	}
+}
+
+func TestMatrixFonts(t *testing.T) {
+	for _, font := range []*MatrixFont{Font5x7, Font8x8} {
+		for r, glyph := range font.Glyphs {
+			if len(glyph) != font.Height {
+				t.Errorf("%s %q: got %d rows, want %d", font.Name, r, len(glyph), font.Height)
+			}
+			for y, row := range glyph {
+				if len(row) != font.Width {
+					t.Errorf("%s %q: row %d has %d LEDs, want %d", font.Name, r, y, len(row), font.Width)
+				}
+			}
+		}
+	}
+
+	for r := range Font5x7.Glyphs {
+		if _, ok := Font8x8.Glyph(r); !ok {
+			t.Errorf("8x8 has no glyph for %q", r)
+		}
+	}
+	if len(Font8x8.Glyphs) != len(Font5x7.Glyphs) {
+		t.Errorf("8x8 has %d glyphs, 5x7 has %d", len(Font8x8.Glyphs), len(Font5x7.Glyphs))
+	}
+}
+
+func TestMatrixFontByName(t *testing.T) {
+	tests := []struct {
+		name string
+		want *MatrixFont
+	}{
+		{"5x7", Font5x7},
+		{"8X8", Font8x8},
+		{"6x9", nil},
+	}
+	for _, tt := range tests {
+		got, err := MatrixFontByName(tt.name)
+		if got != tt.want || (err == nil) != (tt.want != nil) {
+			t.Errorf("%s: got %v, %v", tt.name, got, err)
+		}
+	}
+}
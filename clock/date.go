@@ -0,0 +1,114 @@
+package clock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateMode is how a DigitalClock shows the date
+type DateMode int
+
+const (
+	DateOff       DateMode = iota
+	DateAlternate          // the digits switch to DD.MM.YY for a few seconds
+	DateRow                // a text row under the digits, "SUN 18 OCT"
+)
+
+var dateModeNames = [...]string{"off", "alternate", "row"}
+
+func (m DateMode) String() string {
+	if m < 0 || int(m) >= len(dateModeNames) {
+		return fmt.Sprintf("DateMode(%d)", int(m))
+	}
+	return dateModeNames[m]
+}
+
+// ParseDateMode reads off, alternate or row, empty is off
+func ParseDateMode(name string) (DateMode, error) {
+	if name == "" {
+		return DateOff, nil
+	}
+	for i, n := range dateModeNames {
+		if strings.EqualFold(name, n) {
+			return DateMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown date mode %q, want one of %s", name, strings.Join(dateModeNames[:], ", "))
+}
+
+// Default timing of DateAlternate, 3 seconds of date every 30 seconds
+const (
+	defaultDateEvery = 30 * time.Second
+	defaultDateFor   = 3 * time.Second
+)
+
+// ValidateDateTiming reports an alternating date that would never show
+// or never leave, zero durations use the defaults
+func ValidateDateTiming(every, show time.Duration) error {
+	every, show = dateTiming(every, show)
+	switch {
+	case every < 0 || show < 0:
+		return errors.New("date timing must not be negative")
+	case show >= every:
+		return fmt.Errorf("date shows for %v of every %v, it would never show the time", show, every)
+	}
+	return nil
+}
+
+func dateTiming(every, show time.Duration) (time.Duration, time.Duration) {
+	if every == 0 {
+		every = defaultDateEvery
+	}
+	if show == 0 {
+		show = defaultDateFor
+	}
+	return every, show
+}
+
+// showingDate reports whether the digits show the date at t, for the
+// first DateFor of every DateEvery counted from local midnight
+func (d *DigitalClock) showingDate(t *TickData) bool {
+	if d.opts.DateMode != DateAlternate {
+		return false
+	}
+	every, show := dateTiming(d.opts.DateEvery, d.opts.DateFor)
+	if every <= 0 || show <= 0 {
+		return false
+	}
+
+	midnight := time.Date(t.Year, t.Month, t.Day, 0, 0, 0, 0, t.Location)
+	return t.Time.Sub(midnight)%every < show
+}
+
+// dateDigits is the date for the slots of slotDigits, a decimal point
+// after the day and month and the separators left dark
+func (d *DigitalClock) dateDigits(t *TickData) []int {
+	first, second := t.Day, int(t.Month)
+	if d.opts.MonthFirst {
+		first, second = second, first
+	}
+	firstTens, firstOnes := splitDigits(first)
+	secondTens, secondOnes := splitDigits(second)
+
+	if d.opts.HideSeconds {
+		return append(d.slots[:0], firstTens, firstOnes|DecimalPoint, -1, secondTens, secondOnes)
+	}
+	yearTens, yearOnes := splitDigits(t.Year % 100)
+	return append(d.slots[:0],
+		firstTens, firstOnes|DecimalPoint, -1,
+		secondTens, secondOnes|DecimalPoint, -1,
+		yearTens, yearOnes,
+	)
+}
+
+// dateText is the DateRow text such as "SUN 18 OCT"
+func (d *DigitalClock) dateText(t *TickData) string {
+	weekday := strings.ToUpper(t.Weekday.String()[:3])
+	month := strings.ToUpper(t.Month.String()[:3])
+	if d.opts.MonthFirst {
+		return fmt.Sprintf("%s %s %2d", weekday, month, t.Day)
+	}
+	return fmt.Sprintf("%s %2d %s", weekday, t.Day, month)
+}
@@ -0,0 +1,126 @@
+package clock
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestValidateDateTiming(t *testing.T) {
+	tests := []struct {
+		every, show time.Duration
+		ok          bool
+	}{
+		{0, 0, true}, // 3s of every 30s
+		{time.Minute, 10 * time.Second, true},
+		{0, 29 * time.Second, true},
+		{0, 30 * time.Second, false},
+		{2 * time.Second, 0, false},
+		{time.Second, time.Second, false},
+		{-time.Second, 0, false},
+		{0, -time.Second, false},
+	}
+	for _, tt := range tests {
+		if err := ValidateDateTiming(tt.every, tt.show); (err == nil) != tt.ok {
+			t.Errorf("every %v for %v: got %v, want ok %v", tt.every, tt.show, err, tt.ok)
+		}
+	}
+}
+
+func TestShowingDate(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+30*60)
+	day := func(h, m, s int) time.Time { return time.Date(2026, 10, 18, h, m, s, 0, ist) }
+	tests := []struct {
+		name string
+		opts DigitalOptions
+		at   time.Time
+		want bool
+	}{
+		{"off", DigitalOptions{}, day(0, 0, 1), false},
+		{"row", DigitalOptions{DateMode: DateRow}, day(0, 0, 1), false},
+		{"midnight", DigitalOptions{DateMode: DateAlternate}, day(0, 0, 0), true},
+		{"last date second", DigitalOptions{DateMode: DateAlternate}, day(0, 0, 2), true},
+		{"back to the time", DigitalOptions{DateMode: DateAlternate}, day(0, 0, 3), false},
+		{"half minute", DigitalOptions{DateMode: DateAlternate}, day(10, 15, 31), true},
+		{"custom timing", DigitalOptions{DateMode: DateAlternate, DateEvery: time.Minute, DateFor: 10 * time.Second}, day(10, 15, 9), true},
+		{"custom timing over", DigitalOptions{DateMode: DateAlternate, DateEvery: time.Minute, DateFor: 10 * time.Second}, day(10, 15, 10), false},
+		// counted from local midnight, from UTC midnight 00:07:10 is 250s into a 7m period
+		{"local midnight", DigitalOptions{DateMode: DateAlternate, DateEvery: 7 * time.Minute, DateFor: 30 * time.Second}, day(0, 7, 10), true},
+	}
+	for _, tt := range tests {
+		d := &DigitalClock{opts: tt.opts}
+		if got := d.showingDate(NewTickData(NewFixedClock(tt.at), ist)); got != tt.want {
+			t.Errorf("%s: at %v got %v, want %v", tt.name, tt.at.Format(time.TimeOnly), got, tt.want)
+		}
+	}
+}
+
+func TestDateDigitsAndText(t *testing.T) {
+	october := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) // a Sunday
+	march := time.Date(2009, 3, 5, 12, 0, 0, 0, time.UTC)     // a Thursday
+	dp := DecimalPoint
+	tests := []struct {
+		opts   DigitalOptions
+		at     time.Time
+		digits []int
+		text   string
+	}{
+		{DigitalOptions{}, october, []int{1, 8 | dp, -1, 1, 0 | dp, -1, 2, 6}, "SUN 18 OCT"},
+		{DigitalOptions{MonthFirst: true}, october, []int{1, 0 | dp, -1, 1, 8 | dp, -1, 2, 6}, "SUN OCT 18"},
+		{DigitalOptions{HideSeconds: true}, october, []int{1, 8 | dp, -1, 1, 0}, "SUN 18 OCT"},
+		{DigitalOptions{HideSeconds: true, MonthFirst: true}, october, []int{1, 0 | dp, -1, 1, 8}, "SUN OCT 18"},
+		{DigitalOptions{}, march, []int{0, 5 | dp, -1, 0, 3 | dp, -1, 0, 9}, "THU  5 MAR"},
+		{DigitalOptions{MonthFirst: true}, march, []int{0, 3 | dp, -1, 0, 5 | dp, -1, 0, 9}, "THU MAR  5"},
+	}
+	for _, tt := range tests {
+		d := &DigitalClock{opts: tt.opts}
+		tick := NewTickData(NewFixedClock(tt.at), time.UTC)
+		if got := d.dateDigits(tick); !slices.Equal(got, tt.digits) {
+			t.Errorf("%v %+v: got digits %v, want %v", tt.at.Format(time.DateOnly), tt.opts, got, tt.digits)
+		}
+		if got := d.dateText(tick); got != tt.text {
+			t.Errorf("%v %+v: got text %q, want %q", tt.at.Format(time.DateOnly), tt.opts, got, tt.text)
+		}
+	}
+}
+
+func TestDigitalClockDate(t *testing.T) {
+	d, source := newTestDigitalClock(t, time.Date(2026, 10, 18, 23, 59, 31, 0, time.UTC))
+	tick := NewTickData(source, time.UTC)
+
+	if err := d.SetOptions(DigitalOptions{DateMode: DateAlternate}); err != nil {
+		t.Fatal(err)
+	}
+	digits := func() []string {
+		return []string{litSegments(d.digits[0]), litSegments(d.digits[1]), litSegments(d.digits[3]), litSegments(d.digits[4])}
+	}
+	if got, want := digits(), []string{"bc", "abcdefg.", "bc", "abcdef."}; !slices.Equal(got, want) {
+		t.Errorf("alternate at 23:59:31: got %q, want %q", got, want)
+	}
+	source.Advance(3 * time.Second)
+	tick.Update()
+	d.Update(tick)
+	if got, want := digits(), []string{"abdeg", "abcdg", "acdfg", "abcdfg"}; !slices.Equal(got, want) {
+		t.Errorf("alternate at 23:59:34: got %q, want %q", got, want)
+	}
+
+	if err := d.SetOptions(DigitalOptions{DateMode: DateRow}); err != nil {
+		t.Fatal(err)
+	}
+	if d.caption == nil || d.caption.Text() != "SUN 18 OCT" {
+		t.Fatalf("row: got caption %v", d.caption)
+	}
+	source.Advance(time.Minute)
+	tick.Update()
+	d.Update(tick)
+	if got := d.caption.Text(); got != "MON 19 OCT" {
+		t.Errorf("row after midnight: got %q, want MON 19 OCT", got)
+	}
+
+	if err := d.SetOptions(DigitalOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if d.caption != nil {
+		t.Error("turning the date off left the row")
+	}
+}
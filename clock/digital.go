@@ -1,11 +1,15 @@
 package clock
 
 import (
+	"fmt"
 	"image/color"
+	"slices"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
 )
 
 // Seven segment display segment assignments:
@@ -18,7 +22,7 @@ import (
 //  |     |
 //  e     c
 //  |     |
-//   --d--
+//   --d--  dp
 
 type SegmentRect struct {
 	Position fyne.Position
@@ -26,30 +30,165 @@ type SegmentRect struct {
 }
 
 type SevenSegmentDisplay struct {
-	Segments      [10][7]bool
+	Segments      [glyphCount][7]bool // hex digits 0-F then the Glyph constants
 	SegmentShapes [7]SegmentRect
+	PointShape    SegmentRect // decimal point to the right of the digit
+	Geometry      DigitGeometry
+	outlines      [7][]fyne.Position // segment polygons, nil for rectangles
+	afterglow     time.Duration      // fade time of segments switching off, 0 for none
+	lowPower      bool               // skip the afterglow animation
+	fading        map[fyne.CanvasObject]segmentFade
 	onColor       color.Color
 	offColor      color.Color
 	strokeColor   color.Color
 	x, y          int
 }
 
+// DigitalOptions are the display settings of a DigitalClock
+type DigitalOptions struct {
+	HideLeadingZero bool // blank the hour tens digit when it is 0, " 9:30"
+	HideSeconds     bool // show HH:MM only
+	Separator       Separator
+	ColonBlink      ColonBlink    // needs frame updates to animate, see Sweep
+	DimColon        bool          // blinked off separators show offColor instead of nothing
+	DotMatrix       *MatrixFont   // draw on round LEDs in this font, nil for seven segments
+	Afterglow       time.Duration // segments switching off fade out over this long
+	LowPower        bool          // turns the afterglow off without losing the setting
+
+	// date display, DateEvery and DateFor time DateAlternate and default
+	// to 3 seconds of date every 30 seconds
+	DateMode   DateMode
+	DateEvery  time.Duration
+	DateFor    time.Duration
+	MonthFirst bool // MM.DD.YY and "SUN OCT 18" instead of day first
+
+	// segment geometry, SetOptions rejects values DigitGeometry.Validate does
+	Aspect       float32 // digit height as a multiple of its width, 0 for 110/70
+	Thickness    float32 // segment thickness as a fraction of the digit width, 0 for 1/7
+	Slant        float32 // lean of the top as a fraction of the digit height
+	SegmentStyle SegmentStyle
+}
+
+// Geometry is the digit these options draw at the given width
+func (opts DigitalOptions) Geometry(width float32) DigitGeometry {
+	g := NewDigitGeometry(width)
+	if opts.Aspect != 0 {
+		g.Height = width * opts.Aspect
+	}
+	if opts.Thickness != 0 {
+		g.Thickness = opts.Thickness
+	}
+	g.Slant = opts.Slant
+	g.Style = opts.SegmentStyle
+	return g
+}
+
+// DigitalClock is a widget showing HH:MM:SS on seven segment digits.
+// Digits are drawn at a design size and scaled uniformly to fit the
+// space the widget is given.
 type DigitalClock struct {
+	widget.BaseWidget
 	SevenSegmentDisplay *SevenSegmentDisplay
 	ClockFace           *fyne.Container
+	ZoneLabel           *canvas.Text
+	tick                *TickData
 	digits              []*fyne.Container
+	shown               []int                // digit on show in each slot, see slotDigits
+	slots               [8]int               // scratch space slotDigits returns, saves an allocation per tick
+	meridiem            *fyne.Container      // AM over PM, only in 12 hour mode
+	caption             *AlphanumericDisplay // text row under the digits, see ShowText
+	matrix              *DotMatrixDisplay    // replaces the digits when DotMatrix is set
 	digitWidth          int
 	digitSpacing        int
 	mode24hr            bool
+	opts                DigitalOptions
+	separatorLit        bool
+	width               float32 // design width of all digits and colons
+	matrixWidth         float32 // design width of matrix scaled to the digit height
+	dateDay             int     // YearDay on the DateRow caption, 0 before it is drawn
+}
+
+const (
+	digitalMinScale = 0.5 // smallest scale the widget shrinks to
+	digitalLabelGap = 5
+
+	// AM/PM annunciator at design size, each word lines up with a half of
+	// the digits
+	meridiemWidth    = 30
+	meridiemTextSize = 16
+
+	// ShowText row under the digits at design size
+	captionHeight = 44
+	captionGap    = 10
+)
+
+// Seven segment glyphs after the hex digits 0-9 and A-F, which are
+// their own values
+const (
+	GlyphBlank = iota + 16
+	GlyphMinus
+	GlyphUnderscore
+	GlyphDegree
+	glyphCount
+)
+
+// DecimalPoint is or'ed with a glyph to light the point after it
+const DecimalPoint = 1 << 8
+
+// SevenSegmentGlyph is the glyph for r, hex digits in either case, space,
+// minus, underscore or the degree sign
+func SevenSegmentGlyph(r rune) (int, error) {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0'), nil
+	case r >= 'A' && r <= 'F':
+		return int(r-'A') + 10, nil
+	case r >= 'a' && r <= 'f':
+		return int(r-'a') + 10, nil
+	}
+	switch r {
+	case ' ':
+		return GlyphBlank, nil
+	case '-':
+		return GlyphMinus, nil
+	case '_':
+		return GlyphUnderscore, nil
+	case '°':
+		return GlyphDegree, nil
+	}
+	return 0, fmt.Errorf("no seven segment glyph for %q", r)
+}
+
+// SevenSegmentGlyphs converts text to one glyph per digit, a '.' lights
+// the decimal point of the digit before it, "-12.5°" is four digits
+func SevenSegmentGlyphs(text string) ([]int, error) {
+	var glyphs []int
+	for _, r := range text {
+		if r == '.' {
+			if len(glyphs) == 0 || glyphs[len(glyphs)-1]&DecimalPoint != 0 {
+				glyphs = append(glyphs, GlyphBlank)
+			}
+			glyphs[len(glyphs)-1] |= DecimalPoint
+			continue
+		}
+		glyph, err := SevenSegmentGlyph(r)
+		if err != nil {
+			return nil, err
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	return glyphs, nil
 }
 
 func NewSevenSegmentDisplay(onColor, offColor, strokeColor color.Color) *SevenSegmentDisplay {
-	segmentShapes := newDigitalSegmentShapes()
+	geometry := NewDigitGeometry(faceDigitWidth)
 	segments := newDigitalSegmentBoolMap()
 
 	return &SevenSegmentDisplay{
 		Segments:      segments.Segments,
-		SegmentShapes: segmentShapes,
+		SegmentShapes: geometry.rects(),
+		PointShape:    geometry.pointRect(),
+		Geometry:      geometry,
 		onColor:       onColor,
 		offColor:      offColor,
 		strokeColor:   strokeColor,
@@ -58,185 +197,549 @@ func NewSevenSegmentDisplay(onColor, offColor, strokeColor color.Color) *SevenSe
 	}
 }
 
-func NewDigitalClock(mode24hr bool, onColor, offColor, strokeColor color.Color, digitalWidth, digitalSpacing int) *DigitalClock {
-	time := NewTickData()
+// SetGeometry resizes and reshapes the segments of digits drawn from now on
+func (ssd *SevenSegmentDisplay) SetGeometry(g DigitGeometry) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+
+	ssd.Geometry = g
+	for i, shape := range g.shapes() {
+		ssd.SegmentShapes[i] = shape.rect
+		ssd.outlines[i] = shape.outline
+	}
+	ssd.PointShape = g.pointRect()
+	return nil
+}
+
+func NewDigitalClock(source TimeSource, loc *time.Location, mode24hr bool, onColor, offColor, strokeColor color.Color, digitalWidth, digitalSpacing int) *DigitalClock {
+	time := NewTickData(source, loc)
 
 	ssd := NewSevenSegmentDisplay(onColor, offColor, strokeColor)
+	digitalWidth = sizeOr(digitalWidth, faceDigitWidth)
 
-	HrTensDigit, HrOnesDigit := 0, 0
+	d := &DigitalClock{
+		ZoneLabel:           drawZoneLabel(time.ZoneName()),
+		tick:                time,
+		SevenSegmentDisplay: ssd,
+		digitWidth:          digitalWidth,
+		digitSpacing:        digitalSpacing,
+		mode24hr:            mode24hr,
+	}
 
-	if mode24hr {
-		HrTensDigit = time.Hr24TensDigit
-		HrOnesDigit = time.Hr24OnesDigit
-	} else {
-		HrTensDigit = time.Hr12TensDigit
-		HrOnesDigit = time.Hr12OnesDigit
+	d.ClockFace = container.NewWithoutLayout()
+	if !mode24hr {
+		d.meridiem = drawMeridiem(onColor, offColor)
+		d.setMeridiem(time.PM, false)
 	}
+	d.setGeometry(d.opts) // the default digit at a positive width is always valid
+	d.build()
 
-	colon1 := drawColon(onColor)
-	colon2 := drawColon(onColor)
+	d.ExtendBaseWidget(d)
+	return d
+}
 
-	digitsContainer := []*fyne.Container{
-		ssd.drawDigit(HrTensDigit),
-		ssd.drawDigit(HrOnesDigit),
-		container.NewWithoutLayout(colon1),
-		ssd.drawDigit(time.MinTensDigit),
-		ssd.drawDigit(time.MinOnesDigit),
-		container.NewWithoutLayout(colon2),
-		ssd.drawDigit(time.SecTensDigit),
-		ssd.drawDigit(time.SecOnesDigit),
+// build draws a digit or separator for every slot of the current layout
+func (d *DigitalClock) build() {
+	d.shown = slices.Clone(d.slotDigits(d.tick))
+	d.ClockFace.RemoveAll()
+	d.SevenSegmentDisplay.SetAfterglow(d.opts.Afterglow)
+	d.SevenSegmentDisplay.SetLowPower(d.opts.LowPower)
+	d.width = 0
+	d.matrix = nil
+
+	if font := d.opts.DotMatrix; font != nil {
+		ssd := d.SevenSegmentDisplay
+		d.matrix, _ = NewDotMatrixDisplay(font, len(d.shown), ssd.onColor, ssd.offColor)
+		d.matrix.DrawText(d.matrixText(d.shown, true))
+		d.matrixWidth = d.matrix.designWidth() * d.digitHeight() / d.matrix.designHeight()
+		d.ClockFace.Add(d.matrix)
+		d.width = d.matrixWidth
+		d.shown = nil
 	}
+	d.digits = make([]*fyne.Container, len(d.shown))
 
-	x := float32(0)
-	for i, digitObj := range digitsContainer {
-		var w float32
-		if i == 2 || i == 5 {
-			w = float32(digitalWidth) * 0.2 // or set a colonWidth variable
+	for i, digit := range d.shown {
+		if isColonSlot(i) {
+			d.digits[i] = drawSeparator(d.separatorShapes(), d.SevenSegmentDisplay.onColor)
 		} else {
-			w = float32(digitalWidth)
+			// slotDigits only returns glyphs in the table
+			d.digits[i], _ = d.SevenSegmentDisplay.DrawDigit(digit)
 		}
+		d.ClockFace.Add(d.digits[i])
+		d.width += d.slotWidth(i)
+	}
+	d.width += float32(d.digitSpacing * max(len(d.digits)-1, 0))
 
-		digitObj.Resize(fyne.NewSize(w, digitObj.Size().Height))
-		digitObj.Move(fyne.NewPos(x, 0))
+	if d.meridiem != nil {
+		d.ClockFace.Add(d.meridiem)
+		d.width += float32(d.digitSpacing) + meridiemWidth
+	}
+	if d.caption != nil {
+		d.ClockFace.Add(d.caption)
+	}
+
+	d.separatorLit = true
+	d.paintSeparators()
+}
 
-		x += w + float32(digitalSpacing)
+// setGeometry sizes the segments from the digit width and opts
+func (d *DigitalClock) setGeometry(opts DigitalOptions) error {
+	return d.SevenSegmentDisplay.SetGeometry(opts.Geometry(float32(d.digitWidth)))
+}
+
+// SetOptions changes the layout and style of the display. Geometry that
+// cannot be drawn is an error and leaves the display as it was.
+func (d *DigitalClock) SetOptions(opts DigitalOptions) error {
+	if err := d.setGeometry(opts); err != nil {
+		return err
+	}
+	if d.opts.DateMode == DateRow && opts.DateMode != DateRow {
+		d.ShowText("")
 	}
+	d.opts = opts
+	d.dateDay = 0
+	d.build()
+	d.Update(d.tick)
+	d.Refresh()
+	return nil
+}
 
-	ClockFace := container.NewWithoutLayout()
-	for _, digit := range digitsContainer {
-		ClockFace.Add(digit)
+// ShowText draws text on a row of sixteen segment characters under the
+// digits, such as "MON 18 OCT". Empty text removes the row.
+func (d *DigitalClock) ShowText(text string) error {
+	length := len([]rune(text))
+	switch {
+	case length == 0:
+		if d.caption != nil {
+			d.ClockFace.Remove(d.caption)
+			d.caption = nil
+			d.Refresh()
+		}
+		return nil
+	case d.caption == nil || d.caption.Len() != length:
+		if d.caption != nil {
+			d.ClockFace.Remove(d.caption)
+		}
+		ssd := d.SevenSegmentDisplay
+		caption, err := NewAlphanumericDisplay(16, length, ssd.onColor, ssd.offColor, ssd.strokeColor)
+		if err != nil {
+			return err
+		}
+		d.caption = caption
+		d.ClockFace.Add(caption)
+		defer d.Refresh()
 	}
+	return d.caption.DrawText(text)
+}
 
-	return &DigitalClock{
-		ClockFace:           ClockFace,
-		digits:              digitsContainer,
-		SevenSegmentDisplay: ssd,
-		digitWidth:          digitalWidth,
-		digitSpacing:        digitalSpacing,
-		mode24hr:            mode24hr,
+// design height of a digit
+func (d *DigitalClock) digitHeight() float32 {
+	return d.SevenSegmentDisplay.Geometry.Height
+}
+
+// design height of the digits and the text row under them
+func (d *DigitalClock) designHeight() float32 {
+	if d.caption == nil {
+		return d.digitHeight()
 	}
+	return d.digitHeight() + captionGap + captionHeight
 }
 
-func newDigitalSegmentShapes() [7]SegmentRect {
-	return [7]SegmentRect{
-		{ // a - top horizontal
-			Position: fyne.NewPos(10, 0),
-			Size:     fyne.NewSize(50, 10),
-		},
-		{ // b - top-right vertical
-			Position: fyne.NewPos(60, 10),
-			Size:     fyne.NewSize(10, 40),
-		},
-		{ // c - bottom-right vertical
-			Position: fyne.NewPos(60, 60),
-			Size:     fyne.NewSize(10, 40),
-		},
-		{ // d - bottom horizontal
-			Position: fyne.NewPos(10, 100),
-			Size:     fyne.NewSize(50, 10),
-		},
-		{ // e - bottom-left vertical
-			Position: fyne.NewPos(0, 60),
-			Size:     fyne.NewSize(10, 40),
-		},
-		{ // f - top-left vertical
-			Position: fyne.NewPos(0, 10),
-			Size:     fyne.NewSize(10, 40),
-		},
-		{ // g - middle horizontal
-			Position: fyne.NewPos(10, 50),
-			Size:     fyne.NewSize(50, 10),
-		},
+// Sweep reports whether the clock wants UpdateFrame ticks, which it needs
+// to blink the separators within a second
+func (d *DigitalClock) Sweep() bool {
+	return d.opts.ColonBlink != ColonSteady
+}
+
+// CanvasObject returns the widget itself
+func (d *DigitalClock) CanvasObject() fyne.CanvasObject {
+	return d
+}
+
+func (d *DigitalClock) Name() string {
+	return FaceDigital
+}
+
+func (d *DigitalClock) CreateRenderer() fyne.WidgetRenderer {
+	return &digitalRenderer{clock: d, objects: []fyne.CanvasObject{d.ClockFace, d.ZoneLabel}}
+}
+
+// separators sit in slots 2 and 5 of HH:MM:SS
+func isColonSlot(i int) bool {
+	return i == 2 || i == 5
+}
+
+// design width of the digit or separator in slot i
+func (d *DigitalClock) slotWidth(i int) float32 {
+	if isColonSlot(i) {
+		w := float32(d.digitWidth) * 0.2
+		for _, shape := range d.separatorShapes() {
+			w = max(w, shape.Position.X+shape.Size.Width)
+		}
+		return w
 	}
+	return d.SevenSegmentDisplay.Geometry.slantWidth()
+}
+
+func (d *DigitalClock) separatorShapes() []SegmentRect {
+	return d.opts.Separator.shapes(d.SevenSegmentDisplay.Geometry)
 }
 
 func newDigitalSegmentBoolMap() *SevenSegmentDisplay {
 	return &SevenSegmentDisplay{
-		Segments: [10][7]bool{
+		Segments: [glyphCount][7]bool{
 			//  a, b, c, d, e, f, g
-			{true, true, true, true, true, true, false},     // 0
-			{false, true, true, false, false, false, false}, // 1
-			{true, true, false, true, true, false, true},    // 2
-			{true, true, true, true, false, false, true},    // 3
-			{false, true, true, false, false, true, true},   // 4
-			{true, false, true, true, false, true, true},    // 5
-			{true, false, true, true, true, true, true},     // 6
-			{true, true, true, false, false, false, false},  // 7
-			{true, true, true, true, true, true, true},      // 8
-			{true, true, true, true, false, true, true},     // 9
+			{true, true, true, true, true, true, false},       // 0
+			{false, true, true, false, false, false, false},   // 1
+			{true, true, false, true, true, false, true},      // 2
+			{true, true, true, true, false, false, true},      // 3
+			{false, true, true, false, false, true, true},     // 4
+			{true, false, true, true, false, true, true},      // 5
+			{true, false, true, true, true, true, true},       // 6
+			{true, true, true, false, false, false, false},    // 7
+			{true, true, true, true, true, true, true},        // 8
+			{true, true, true, true, false, true, true},       // 9
+			{true, true, true, false, true, true, true},       // A
+			{false, false, true, true, true, true, true},      // b
+			{true, false, false, true, true, true, false},     // C
+			{false, true, true, true, true, false, true},      // d
+			{true, false, false, true, true, true, true},      // E
+			{true, false, false, false, true, true, true},     // F
+			{false, false, false, false, false, false, false}, // blank
+			{false, false, false, false, false, false, true},  // minus
+			{false, false, false, true, false, false, false},  // underscore
+			{true, true, false, false, false, true, true},     // degree
 		},
-		SegmentShapes: newDigitalSegmentShapes(),
+		SegmentShapes: NewDigitGeometry(faceDigitWidth).rects(),
+	}
+}
+
+// lit is the state of segments a to g then the decimal point for glyph
+func (ssd *SevenSegmentDisplay) lit(glyph int) ([8]bool, error) {
+	var lit [8]bool
+	base := glyph &^ DecimalPoint
+	if base < 0 || base >= len(ssd.Segments) {
+		return lit, fmt.Errorf("no seven segment glyph %d", glyph)
 	}
+	copy(lit[:], ssd.Segments[base][:])
+	lit[7] = glyph&DecimalPoint != 0
+	return lit, nil
 }
 
-func (ssd *SevenSegmentDisplay) drawDigit(digit int) *fyne.Container {
+// DrawDigit draws glyph, a hex digit or Glyph constant optionally or'ed
+// with DecimalPoint. An unknown glyph is drawn blank and reported.
+func (ssd *SevenSegmentDisplay) DrawDigit(glyph int) (*fyne.Container, error) {
+	lit, err := ssd.lit(glyph)
 	segments := []fyne.CanvasObject{}
 
-	for i, seg := range ssd.SegmentShapes {
-		segColor := ssd.offColor
-		strokeColor := ssd.offColor
+	for i, seg := range ssd.shapes() {
+		var segment fyne.CanvasObject
+		if i < len(ssd.outlines) && ssd.outlines[i] != nil {
+			segment = canvas.NewRaster(nil)
+		} else {
+			rect := canvas.NewRectangle(ssd.offColor)
+			rect.StrokeWidth = 2
+			segment = rect
+		}
+		ssd.paintSegment(segment, i, lit[i])
+		segment.Resize(seg.Size)
+		//apply offset
+		segment.Move(fyne.NewPos(seg.Position.X+float32(ssd.x), seg.Position.Y+float32(ssd.y)))
+
+		segments = append(segments, segment)
+	}
+
+	return container.NewWithoutLayout(segments...), err
+}
+
+// SetDigit changes a digit made by DrawDigit from one glyph to another,
+// only the segments that switch on or off are recoloured and refreshed.
+// An unknown glyph leaves the digit as it was.
+func (ssd *SevenSegmentDisplay) SetDigit(c *fyne.Container, from, to int) error {
+	was, err := ssd.lit(from)
+	if err != nil {
+		return err
+	}
+	lit, err := ssd.lit(to)
+	if err != nil {
+		return err
+	}
 
-		if ssd.Segments[digit][i] {
-			segColor = ssd.onColor
-			strokeColor = ssd.strokeColor
+	for i, obj := range c.Objects {
+		if was[i] == lit[i] {
+			continue
 		}
+		if !lit[i] && ssd.glowing() {
+			ssd.fadeOut(obj, i)
+			continue
+		}
+		ssd.stopFade(obj)
+		ssd.paintSegment(obj, i, lit[i])
+		obj.Refresh()
+	}
+	return nil
+}
 
-		rect := canvas.NewRectangle(segColor)
-		rect.StrokeWidth = 2
-		rect.StrokeColor = strokeColor
-		rect.Resize(seg.Size)
-		//apply offset
-		rect.Move(fyne.NewPos(seg.Position.X+float32(ssd.x), seg.Position.Y+float32(ssd.y)))
+// shapes of the segments a to g then the decimal point
+func (ssd *SevenSegmentDisplay) shapes() []SegmentRect {
+	return append(ssd.SegmentShapes[:], ssd.PointShape)
+}
+
+// paintSegment colours segment i, a rectangle or an outline raster
+func (ssd *SevenSegmentDisplay) paintSegment(obj fyne.CanvasObject, i int, on bool) {
+	if on {
+		ssd.paintColors(obj, i, ssd.onColor, ssd.strokeColor)
+	} else {
+		ssd.paintColors(obj, i, ssd.offColor, ssd.offColor)
+	}
+}
 
-		segments = append(segments, rect)
+func (ssd *SevenSegmentDisplay) paintColors(obj fyne.CanvasObject, i int, fill, stroke color.Color) {
+	switch segment := obj.(type) {
+	case *canvas.Rectangle:
+		segment.FillColor = fill
+		segment.StrokeColor = stroke
+	case *canvas.Raster:
+		segment.Generator = fillOutline(ssd.outlines[i], ssd.SegmentShapes[i].Size, fill)
 	}
+}
 
-	return container.NewWithoutLayout(segments...)
+func drawSeparator(shapes []SegmentRect, onColor color.Color) *fyne.Container {
+	separator := container.NewWithoutLayout()
+	for _, shape := range shapes {
+		dot := canvas.NewRectangle(onColor)
+		dot.Resize(shape.Size)
+		dot.Move(shape.Position)
+		separator.Add(dot)
+	}
+	return separator
 }
 
-func drawColon(onColor color.Color) *fyne.Container {
-	topDot := canvas.NewRectangle(onColor)
-	topDot.Resize(fyne.NewSize(10, 10))
+// paintSeparators colours the separators for separatorLit
+func (d *DigitalClock) paintSeparators() {
+	fill := d.SevenSegmentDisplay.onColor
+	if !d.separatorLit {
+		fill = color.Transparent
+		if d.opts.DimColon {
+			fill = d.SevenSegmentDisplay.offColor
+		}
+	}
 
-	bottomDot := canvas.NewRectangle(onColor)
-	bottomDot.Resize(fyne.NewSize(10, 10))
+	for i, slot := range d.digits {
+		if !isColonSlot(i) {
+			continue
+		}
+		for _, obj := range slot.Objects {
+			dot := obj.(*canvas.Rectangle)
+			dot.FillColor = fill
+			dot.Refresh()
+		}
+	}
+}
 
-	colon := container.NewWithoutLayout(topDot, bottomDot)
-	topDot.Move(fyne.NewPos(0, 20))
-	bottomDot.Move(fyne.NewPos(0, 60))
+func drawMeridiem(onColor, offColor color.Color) *fyne.Container {
+	am := canvas.NewText("AM", offColor)
+	pm := canvas.NewText("PM", offColor)
+	for _, text := range []*canvas.Text{am, pm} {
+		text.TextStyle = fyne.TextStyle{Bold: true}
+		text.TextSize = meridiemTextSize
+	}
+	return container.NewWithoutLayout(am, pm)
+}
 
-	return colon
+// setMeridiem lights AM or PM, or neither when dark
+func (d *DigitalClock) setMeridiem(pm, dark bool) {
+	if d.meridiem == nil {
+		return
+	}
+
+	lit := d.meridiem.Objects[0]
+	switch {
+	case dark:
+		lit = nil
+	case pm:
+		lit = d.meridiem.Objects[1]
+	}
+	for _, obj := range d.meridiem.Objects {
+		text := obj.(*canvas.Text)
+		want := d.SevenSegmentDisplay.offColor
+		if obj == lit {
+			want = d.SevenSegmentDisplay.onColor
+		}
+		if text.Color != want {
+			text.Color = want
+			text.Refresh()
+		}
+	}
 }
 
 func (d *DigitalClock) Update(t *TickData) {
+	d.tick.Sync(t)
+	t = d.tick
+
+	if zone := t.ZoneName(); d.ZoneLabel.Text != zone {
+		d.ZoneLabel.Text = zone
+		d.ZoneLabel.Refresh()
+	}
 
-	HrTensDigit, HrOnesDigit := 0, 0
+	if d.opts.DateMode == DateRow && t.YearDay != d.dateDay {
+		d.dateDay = t.YearDay
+		d.ShowText(d.dateText(t))
+	}
 
+	// the separators and AM/PM go dark while the digits show the date
+	date := d.showingDate(t)
+	lit := d.opts.ColonBlink.lit(t) && !date
+	d.setMeridiem(t.PM, date)
+
+	if d.matrix != nil {
+		// the display only relights the LEDs that change
+		d.matrix.DrawText(d.matrixText(d.slotDigits(t), lit))
+		return
+	}
+
+	// the segments keep their place, only recolour digits that changed
+	for i, digit := range d.slotDigits(t) {
+		if isColonSlot(i) || digit == d.shown[i] {
+			continue
+		}
+		d.SevenSegmentDisplay.SetDigit(d.digits[i], d.shown[i], digit)
+		d.shown[i] = digit
+	}
+
+	if lit != d.separatorLit {
+		d.separatorLit = lit
+		d.paintSeparators()
+	}
+}
+
+// matrixText is slots from slotDigits as text for the dot matrix, blinked
+// off separators are blank and a decimal point takes the separator after it
+func (d *DigitalClock) matrixText(slots []int, lit bool) string {
+	text := make([]rune, len(slots))
+	for i, digit := range slots {
+		switch {
+		case isColonSlot(i) && i > 0 && slots[i-1]&DecimalPoint != 0:
+			text[i] = '.'
+		case isColonSlot(i) && lit:
+			text[i] = d.opts.Separator.rune()
+		case isColonSlot(i), digit == GlyphBlank:
+			text[i] = ' '
+		default:
+			text[i] = '0' + rune(digit&^DecimalPoint)
+		}
+	}
+	return string(text)
+}
+
+// slotDigits is the digit to show in each slot of HH:MM:SS, or HH:MM
+// without seconds, separator slots are -1. The date takes the same slots
+// while it shows. The slice is reused by the next call.
+func (d *DigitalClock) slotDigits(t *TickData) []int {
+	if d.showingDate(t) {
+		return d.dateDigits(t)
+	}
+
+	hrTens, hrOnes := t.Hr12TensDigit, t.Hr12OnesDigit
 	if d.mode24hr {
-		HrTensDigit = t.Hr24TensDigit
-		HrOnesDigit = t.Hr24OnesDigit
-	} else {
-		HrTensDigit = t.Hr12TensDigit
-		HrOnesDigit = t.Hr12OnesDigit
+		hrTens, hrOnes = t.Hr24TensDigit, t.Hr24OnesDigit
+	}
+	if d.opts.HideLeadingZero && hrTens == 0 {
+		hrTens = GlyphBlank
 	}
 
-	newDigits := []*fyne.Container{
-		d.SevenSegmentDisplay.drawDigit(HrTensDigit),
-		d.SevenSegmentDisplay.drawDigit(HrOnesDigit),
-		d.SevenSegmentDisplay.drawDigit(t.MinTensDigit),
-		d.SevenSegmentDisplay.drawDigit(t.MinOnesDigit),
-		d.SevenSegmentDisplay.drawDigit(t.SecTensDigit),
-		d.SevenSegmentDisplay.drawDigit(t.SecOnesDigit),
+	if d.opts.HideSeconds {
+		return append(d.slots[:0], hrTens, hrOnes, -1, t.MinTensDigit, t.MinOnesDigit)
 	}
+	return append(d.slots[:0],
+		hrTens, hrOnes, -1,
+		t.MinTensDigit, t.MinOnesDigit, -1,
+		t.SecTensDigit, t.SecOnesDigit,
+	)
+}
 
-	// Only refresh digits
-	digitIndex := 0
-	for _, obj := range d.ClockFace.Objects {
-		container, ok := obj.(*fyne.Container)
-		if ok && len(container.Objects) == 7 {
-			container.Objects = newDigits[digitIndex].Objects
-			container.Refresh()
-			digitIndex++
+type digitalRenderer struct {
+	clock   *DigitalClock
+	objects []fyne.CanvasObject
+}
+
+// Layout scales the digits to the largest size that fits while keeping
+// their aspect ratio, centred above the zone label
+func (r *digitalRenderer) Layout(size fyne.Size) {
+	d := r.clock
+	labelHeight := d.ZoneLabel.MinSize().Height
+	avail := fyne.NewSize(size.Width, size.Height-labelHeight-digitalLabelGap)
+
+	height := d.designHeight()
+	scale := max(min(avail.Width/d.width, avail.Height/height), 0)
+	x := (avail.Width - d.width*scale) / 2
+	y := (avail.Height - height*scale) / 2
+
+	d.ClockFace.Resize(avail)
+	d.ClockFace.Move(fyne.NewPos(0, 0))
+
+	for i, digit := range d.digits {
+		w := d.slotWidth(i)
+		digit.Resize(fyne.NewSize(w*scale, d.digitHeight()*scale))
+		digit.Move(fyne.NewPos(x, y))
+
+		shapes := d.SevenSegmentDisplay.shapes()
+		if isColonSlot(i) {
+			shapes = d.separatorShapes()
+		}
+		for j, obj := range digit.Objects {
+			if j >= len(shapes) {
+				break
+			}
+			obj.Resize(fyne.NewSize(shapes[j].Size.Width*scale, shapes[j].Size.Height*scale))
+			obj.Move(fyne.NewPos(shapes[j].Position.X*scale, shapes[j].Position.Y*scale))
 		}
+
+		x += (w + float32(d.digitSpacing)) * scale
+	}
+
+	if d.matrix != nil {
+		d.matrix.Resize(fyne.NewSize(d.matrixWidth*scale, d.digitHeight()*scale))
+		d.matrix.Move(fyne.NewPos(x, y))
+		x += (d.matrixWidth + float32(d.digitSpacing)) * scale
+	}
+
+	if d.meridiem != nil {
+		d.meridiem.Resize(fyne.NewSize(meridiemWidth*scale, d.digitHeight()*scale))
+		d.meridiem.Move(fyne.NewPos(x, y))
+		for i, obj := range d.meridiem.Objects {
+			text := obj.(*canvas.Text)
+			text.TextSize = meridiemTextSize * scale
+			textSize := text.MinSize()
+			// centre each word on the upper or lower half of the digits
+			half := d.digitHeight() * scale / 2
+			text.Resize(textSize)
+			text.Move(fyne.NewPos(0, half*float32(i)+(half-textSize.Height)/2))
+		}
+	}
+
+	if d.caption != nil {
+		d.caption.Resize(fyne.NewSize(d.width*scale, captionHeight*scale))
+		d.caption.Move(fyne.NewPos((avail.Width-d.width*scale)/2, y+(d.digitHeight()+captionGap)*scale))
 	}
+
+	d.ZoneLabel.Resize(fyne.NewSize(size.Width, labelHeight))
+	d.ZoneLabel.Move(fyne.NewPos(0, size.Height-labelHeight))
 }
+
+func (r *digitalRenderer) MinSize() fyne.Size {
+	d := r.clock
+	return fyne.NewSize(d.width*digitalMinScale, d.designHeight()*digitalMinScale+digitalLabelGap+d.ZoneLabel.MinSize().Height)
+}
+
+func (r *digitalRenderer) Refresh() {
+	r.Layout(r.clock.Size())
+	r.clock.ClockFace.Refresh()
+}
+
+func (r *digitalRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *digitalRenderer) Destroy() {}
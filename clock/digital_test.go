@@ -0,0 +1,130 @@
+package clock
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+// newTestDigitalClock is a 24 hour UTC clock showing at
+func newTestDigitalClock(tb testing.TB, at time.Time) (*DigitalClock, *FixedClock) {
+	test.NewTempApp(tb)
+
+	source := NewFixedClock(at)
+	return NewDigitalClock(source, time.UTC, true, testOn, testOff, testStroke, 70, 10), source
+}
+
+func newBenchDigitalClock(b *testing.B) (*DigitalClock, *FixedClock) {
+	return newTestDigitalClock(b, time.Date(2024, 10, 18, 9, 59, 0, 0, time.UTC))
+}
+
+// BenchmarkDigitalClockUpdate ticks the clock a second at a time, only the
+// segments that change are recoloured
+func BenchmarkDigitalClockUpdate(b *testing.B) {
+	d, source := newBenchDigitalClock(b)
+	tick := NewTickData(source, time.UTC)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		source.Advance(time.Second)
+		tick.Update()
+		d.Update(tick)
+	}
+}
+
+// BenchmarkDigitalClockRedraw is the old Update, six digits of new
+// rectangles every tick, kept to compare against
+func BenchmarkDigitalClockRedraw(b *testing.B) {
+	d, source := newBenchDigitalClock(b)
+	tick := NewTickData(source, time.UTC)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		source.Advance(time.Second)
+		tick.Update()
+		for i, digit := range d.slotDigits(tick) {
+			if !isColonSlot(i) {
+				c, _ := d.SevenSegmentDisplay.DrawDigit(digit)
+				d.digits[i].Objects = c.Objects
+			}
+		}
+		d.Refresh()
+	}
+}
+
+// litSegments names the segments of a drawn digit showing the on colour,
+// "abcdefg." for an 8 with its decimal point
+func litSegments(c *fyne.Container) string {
+	var lit strings.Builder
+	for i, obj := range c.Objects {
+		if obj.(*canvas.Rectangle).FillColor == color.Color(testOn) {
+			lit.WriteString(string("abcdefg."[i]))
+		}
+	}
+	return lit.String()
+}
+
+func TestSevenSegmentGlyphs(t *testing.T) {
+	test.NewTempApp(t)
+	ssd := NewSevenSegmentDisplay(testOn, testOff, testStroke)
+
+	tests := []struct {
+		text string
+		want []string // lit segments of each digit
+	}{
+		{"0123456789", []string{"abcdef", "bc", "abdeg", "abcdg", "bcfg", "acdfg", "acdefg", "abc", "abcdefg", "abcdfg"}},
+		{"ABCDEF", []string{"abcefg", "cdefg", "adef", "bcdeg", "adefg", "aefg"}},
+		{"abcdef", []string{"abcefg", "cdefg", "adef", "bcdeg", "adefg", "aefg"}},
+		{" -_°", []string{"", "g", "d", "abfg"}},
+		{"-12.5°", []string{"g", "bc", "abdeg.", "acdfg", "abfg"}},
+		{"8.", []string{"abcdefg."}},
+		{".5", []string{".", "acdfg"}},
+		{"1..", []string{"bc.", "."}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		glyphs, err := SevenSegmentGlyphs(tt.text)
+		if err != nil {
+			t.Errorf("%q: %v", tt.text, err)
+			continue
+		}
+		var got []string
+		for _, glyph := range glyphs {
+			c, err := ssd.DrawDigit(glyph)
+			if err != nil {
+				t.Fatalf("%q glyph %d: %v", tt.text, glyph, err)
+			}
+			got = append(got, litSegments(c))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"G", "12:30", "é"} {
+		if _, err := SevenSegmentGlyphs(text); err == nil {
+			t.Errorf("%q: got no error", text)
+		}
+	}
+}
+
+func TestDrawDigitUnknown(t *testing.T) {
+	test.NewTempApp(t)
+	ssd := NewSevenSegmentDisplay(testOn, testOff, testStroke)
+
+	for _, glyph := range []int{-1, glyphCount, glyphCount | DecimalPoint, 1 << 9} {
+		c, err := ssd.DrawDigit(glyph)
+		if err == nil {
+			t.Errorf("glyph %d: got no error", glyph)
+		}
+		if c == nil || len(c.Objects) != 8 || litSegments(c) != "" {
+			t.Errorf("glyph %d: want a blank digit", glyph)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package clock
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MatrixFont is a bitmap font for a DotMatrixDisplay, every glyph is
+// Height rows of Width LEDs
+type MatrixFont struct {
+	Name          string
+	Width, Height int
+	Glyphs        map[rune][][]bool
+}
+
+// Glyph is the LEDs lit for r, lower case letters use the upper case glyph
+func (f *MatrixFont) Glyph(r rune) ([][]bool, bool) {
+	glyph, ok := f.Glyphs[unicode.ToUpper(r)]
+	return glyph, ok
+}
+
+// glyphs5x7 draws each character as rows of # for a lit LED
+var glyphs5x7 = map[rune][]string{
+	' ':  {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'0':  {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1':  {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2':  {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3':  {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4':  {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5':  {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6':  {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7':  {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8':  {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9':  {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'A':  {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B':  {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C':  {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D':  {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###.."},
+	'E':  {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F':  {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G':  {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H':  {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I':  {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J':  {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K':  {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L':  {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M':  {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N':  {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O':  {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P':  {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q':  {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R':  {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S':  {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T':  {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V':  {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W':  {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X':  {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y':  {"#...#", "#...#", "#...#", ".#.#.", "..#..", "..#..", "..#.."},
+	'Z':  {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	':':  {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+	'.':  {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	',':  {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	'-':  {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'+':  {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'=':  {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'/':  {".....", "....#", "...#.", "..#..", ".#...", "#....", "....."},
+	'*':  {".....", "..#..", "#.#.#", ".###.", "#.#.#", "..#..", "....."},
+	'#':  {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#."},
+	'%':  {"##...", "##..#", "...#.", "..#..", ".#...", "#..##", "...##"},
+	'!':  {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'?':  {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'\'': {".##..", "..#..", ".#...", ".....", ".....", ".....", "....."},
+	'(':  {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#."},
+	')':  {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#..."},
+	'<':  {"...#.", "..#..", ".#...", "#....", ".#...", "..#..", "...#."},
+	'>':  {".#...", "..#..", "...#.", "....#", "...#.", "..#..", ".#..."},
+	'°':  {".##..", "#..#.", "#..#.", ".##..", ".....", ".....", "....."},
+}
+
+// glyphs8x8 is a heavier design for 8x8 boards with strokes two LEDs
+// wide. The last column and row are left for spacing, only the tails of
+// , and _ and the arms of * reach into them.
+var glyphs8x8 = map[rune][]string{
+	' ':  {"........", "........", "........", "........", "........", "........", "........", "........"},
+	'0':  {".#####..", "##...##.", "##..###.", "##.####.", "####.##.", "###..##.", ".#####..", "........"},
+	'1':  {"..##....", ".###....", "..##....", "..##....", "..##....", "..##....", "######..", "........"},
+	'2':  {".####...", "##..##..", "....##..", "..###...", ".##.....", "##..##..", "######..", "........"},
+	'3':  {".####...", "##..##..", "....##..", "..###...", "....##..", "##..##..", ".####...", "........"},
+	'4':  {"...###..", "..####..", ".##.##..", "##..##..", "#######.", "....##..", "...####.", "........"},
+	'5':  {"######..", "##......", "#####...", "....##..", "....##..", "##..##..", ".####...", "........"},
+	'6':  {"..###...", ".##.....", "##......", "#####...", "##..##..", "##..##..", ".####...", "........"},
+	'7':  {"######..", "##..##..", "....##..", "...##...", "..##....", "..##....", "..##....", "........"},
+	'8':  {".####...", "##..##..", "##..##..", ".####...", "##..##..", "##..##..", ".####...", "........"},
+	'9':  {".####...", "##..##..", "##..##..", ".#####..", "....##..", "...##...", ".###....", "........"},
+	'A':  {"..##....", ".####...", "##..##..", "##..##..", "######..", "##..##..", "##..##..", "........"},
+	'B':  {"######..", ".##..##.", ".##..##.", ".#####..", ".##..##.", ".##..##.", "######..", "........"},
+	'C':  {"..####..", ".##..##.", "##......", "##......", "##......", ".##..##.", "..####..", "........"},
+	'D':  {"#####...", ".##.##..", ".##..##.", ".##..##.", ".##..##.", ".##.##..", "#####...", "........"},
+	'E':  {"#######.", ".##...#.", ".##.#...", ".####...", ".##.#...", ".##...#.", "#######.", "........"},
+	'F':  {"#######.", ".##...#.", ".##.#...", ".####...", ".##.#...", ".##.....", "####....", "........"},
+	'G':  {"..####..", ".##..##.", "##......", "##......", "##..###.", ".##..##.", "..#####.", "........"},
+	'H':  {"##..##..", "##..##..", "##..##..", "######..", "##..##..", "##..##..", "##..##..", "........"},
+	'I':  {".####...", "..##....", "..##....", "..##....", "..##....", "..##....", ".####...", "........"},
+	'J':  {"...####.", "....##..", "....##..", "....##..", "##..##..", "##..##..", ".####...", "........"},
+	'K':  {"###..##.", ".##..##.", ".##.##..", ".####...", ".##.##..", ".##..##.", "###..##.", "........"},
+	'L':  {"####....", ".##.....", ".##.....", ".##.....", ".##...#.", ".##..##.", "#######.", "........"},
+	'M':  {"##...##.", "###.###.", "#######.", "#######.", "##.#.##.", "##...##.", "##...##.", "........"},
+	'N':  {"##...##.", "###..##.", "####.##.", "##.####.", "##..###.", "##...##.", "##...##.", "........"},
+	'O':  {"..###...", ".##.##..", "##...##.", "##...##.", "##...##.", ".##.##..", "..###...", "........"},
+	'P':  {"######..", ".##..##.", ".##..##.", ".#####..", ".##.....", ".##.....", "####....", "........"},
+	'Q':  {".####...", "##..##..", "##..##..", "##..##..", "##.###..", ".####...", "...###..", "........"},
+	'R':  {"######..", ".##..##.", ".##..##.", ".#####..", ".##.##..", ".##..##.", "###..##.", "........"},
+	'S':  {".####...", "##..##..", "###.....", ".###....", "...###..", "##..##..", ".####...", "........"},
+	'T':  {"######..", "#.##.#..", "..##....", "..##....", "..##....", "..##....", ".####...", "........"},
+	'U':  {"##..##..", "##..##..", "##..##..", "##..##..", "##..##..", "##..##..", "######..", "........"},
+	'V':  {"##..##..", "##..##..", "##..##..", "##..##..", "##..##..", ".####...", "..##....", "........"},
+	'W':  {"##...##.", "##...##.", "##...##.", "##.#.##.", "#######.", "###.###.", "##...##.", "........"},
+	'X':  {"##...##.", "##...##.", ".##.##..", "..###...", "..###...", ".##.##..", "##...##.", "........"},
+	'Y':  {"##..##..", "##..##..", "##..##..", ".####...", "..##....", "..##....", ".####...", "........"},
+	'Z':  {"#######.", "##...##.", "#...##..", "...##...", "..##..#.", ".##..##.", "#######.", "........"},
+	':':  {"........", "..##....", "..##....", "........", "........", "..##....", "..##....", "........"},
+	'.':  {"........", "........", "........", "........", "........", "..##....", "..##....", "........"},
+	',':  {"........", "........", "........", "........", "........", "..##....", "..##....", ".##....."},
+	'-':  {"........", "........", "........", "######..", "........", "........", "........", "........"},
+	'+':  {"........", "..##....", "..##....", "######..", "..##....", "..##....", "........", "........"},
+	'=':  {"........", "........", "######..", "........", "........", "######..", "........", "........"},
+	'_':  {"........", "........", "........", "........", "........", "........", "........", "########"},
+	'/':  {".....##.", "....##..", "...##...", "..##....", ".##.....", "##......", "#.......", "........"},
+	'*':  {"........", ".##..##.", "..####..", "########", "..####..", ".##..##.", "........", "........"},
+	'#':  {".##.##..", ".##.##..", "#######.", ".##.##..", "#######.", ".##.##..", ".##.##..", "........"},
+	'%':  {"........", "##...##.", "##..##..", "...##...", "..##....", ".##..##.", "##...##.", "........"},
+	'!':  {"..##....", ".####...", ".####...", "..##....", "..##....", "........", "..##....", "........"},
+	'?':  {".####...", "##..##..", "....##..", "...##...", "..##....", "........", "..##....", "........"},
+	'\'': {".##.....", ".##.....", "##......", "........", "........", "........", "........", "........"},
+	'(':  {"...##...", "..##....", ".##.....", ".##.....", ".##.....", "..##....", "...##...", "........"},
+	')':  {".##.....", "..##....", "...##...", "...##...", "...##...", "..##....", ".##.....", "........"},
+	'<':  {"...##...", "..##....", ".##.....", "##......", ".##.....", "..##....", "...##...", "........"},
+	'>':  {".##.....", "..##....", "...##...", "....##..", "...##...", "..##....", ".##.....", "........"},
+	'°':  {"..###...", ".##.##..", ".##.##..", "..###...", "........", "........", "........", "........"},
+}
+
+// Font5x7 is the classic character LCD and LED sign font
+var Font5x7 = newMatrixFont("5x7", 5, 7, glyphs5x7)
+
+// Font8x8 is a heavier font for larger boards
+var Font8x8 = newMatrixFont("8x8", 8, 8, glyphs8x8)
+
+// newMatrixFont reads a glyph table where # is a lit LED
+func newMatrixFont(name string, width, height int, glyphs map[rune][]string) *MatrixFont {
+	f := &MatrixFont{Name: name, Width: width, Height: height, Glyphs: map[rune][][]bool{}}
+	for r, rows := range glyphs {
+		glyph := make([][]bool, len(rows))
+		for y, row := range rows {
+			glyph[y] = make([]bool, len(row))
+			for x, c := range row {
+				glyph[y][x] = c == '#'
+			}
+		}
+		f.Glyphs[r] = glyph
+	}
+	return f
+}
+
+// MatrixFontByName finds Font5x7 or Font8x8 by their size, "5x7" or "8x8"
+func MatrixFontByName(name string) (*MatrixFont, error) {
+	for _, f := range []*MatrixFont{Font5x7, Font8x8} {
+		if strings.EqualFold(name, f.Name) {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown dot matrix font %q, want 5x7 or 8x8", name)
+}
+
+const (
+	ledPitch    = 10 // design distance between LED centres
+	ledDiameter = 8
+	ledMinScale = 0.25
+)
+
+// DotMatrixDisplay is a row of characters drawn on a grid of round LEDs.
+// It is drawn at a design size and scaled to fit like the other displays.
+type DotMatrixDisplay struct {
+	widget.BaseWidget
+	charRow  // Width*Height LEDs each character, row by row
+	font     *MatrixFont
+	onColor  color.Color
+	offColor color.Color
+}
+
+// NewDotMatrixDisplay makes a display of length characters in font
+func NewDotMatrixDisplay(font *MatrixFont, length int, onColor, offColor color.Color) (*DotMatrixDisplay, error) {
+	m := &DotMatrixDisplay{
+		font:     font,
+		onColor:  onColor,
+		offColor: offColor,
+	}
+
+	// a column of LEDs is left between characters
+	size := fyne.NewSize(float32(font.Width*ledPitch), float32(font.Height*ledPitch))
+	row, err := newCharRow(m, length, size, ledPitch, ledMinScale)
+	if err != nil {
+		return nil, err
+	}
+	m.charRow = row
+	m.build(func() *fyne.Container {
+		char := container.NewWithoutLayout()
+		for range font.Width * font.Height {
+			char.Add(canvas.NewCircle(offColor))
+		}
+		return char
+	})
+
+	m.ExtendBaseWidget(m)
+	return m, nil
+}
+
+// setCell recolours the LEDs of character i that change
+func (m *DotMatrixDisplay) setCell(i int, r rune) bool {
+	glyph, ok := m.font.Glyph(r)
+	for j, obj := range m.chars[i].Objects {
+		x, y := j%m.font.Width, j/m.font.Width
+		fill := m.offColor
+		if y < len(glyph) && x < len(glyph[y]) && glyph[y][x] {
+			fill = m.onColor
+		}
+
+		led := obj.(*canvas.Circle)
+		if led.FillColor != fill {
+			led.FillColor = fill
+			led.Refresh()
+		}
+	}
+	return ok
+}
+
+func (m *DotMatrixDisplay) layoutCell(char *fyne.Container, scale float32) {
+	inset := float32(ledPitch-ledDiameter) / 2 * scale
+	for j, led := range char.Objects {
+		col, row := float32(j%m.font.Width), float32(j/m.font.Width)
+		led.Resize(fyne.NewSquareSize(ledDiameter * scale))
+		led.Move(fyne.NewPos(col*ledPitch*scale+inset, row*ledPitch*scale+inset))
+	}
+}
+
+func (m *DotMatrixDisplay) CreateRenderer() fyne.WidgetRenderer {
+	return newCharRowRenderer(m, &m.charRow)
+}
@@ -0,0 +1,116 @@
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeUnit names the field a TickEvent is about
+type TimeUnit int
+
+const (
+	UnitSecond TimeUnit = iota
+	UnitMinute
+	UnitHour
+	UnitDay
+)
+
+func (u TimeUnit) String() string {
+	switch u {
+	case UnitSecond:
+		return "second"
+	case UnitMinute:
+		return "minute"
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	}
+	return fmt.Sprintf("TimeUnit(%d)", int(u))
+}
+
+// TickEvent is emitted when a unit of the tick changes value.
+// Hours are 0-23 and days are the day of the year.
+type TickEvent struct {
+	Unit     TimeUnit
+	Previous int
+	Current  int
+	Time     time.Time // instant of the update that caused the event
+	// Rollover is set when the next larger unit changed too, e.g. a
+	// second event at 12:00:00 after 11:59:59
+	Rollover bool
+}
+
+type TickHandler func(e TickEvent)
+
+// tickSubscribers holds the handlers registered on a TickData
+type tickSubscribers struct {
+	units    [UnitDay + 1][]TickHandler
+	rollover []TickHandler
+}
+
+// OnSecond calls h whenever the second changes
+func (t *TickData) OnSecond(h TickHandler) {
+	t.subscribers.units[UnitSecond] = append(t.subscribers.units[UnitSecond], h)
+}
+
+// OnMinute calls h whenever the minute changes
+func (t *TickData) OnMinute(h TickHandler) {
+	t.subscribers.units[UnitMinute] = append(t.subscribers.units[UnitMinute], h)
+}
+
+// OnHour calls h whenever the hour changes
+func (t *TickData) OnHour(h TickHandler) {
+	t.subscribers.units[UnitHour] = append(t.subscribers.units[UnitHour], h)
+}
+
+// OnDay calls h whenever the date changes
+func (t *TickData) OnDay(h TickHandler) {
+	t.subscribers.units[UnitDay] = append(t.subscribers.units[UnitDay], h)
+}
+
+// OnRollover calls h for every unit that wrapped, after the unit's own
+// handlers have run
+func (t *TickData) OnRollover(h TickHandler) {
+	t.subscribers.rollover = append(t.subscribers.rollover, h)
+}
+
+// emit compares the previous instant with the current one and fires
+// events from the largest unit down
+func (t *TickData) emit(prev time.Time) {
+	if prev.IsZero() {
+		return
+	}
+	prev = prev.In(t.Location)
+	now := t.Time
+
+	prevYear, prevDay := prev.Year(), prev.YearDay()
+	dayChanged := prevYear != now.Year() || prevDay != now.YearDay()
+	hourChanged := dayChanged || prev.Hour() != now.Hour()
+	minuteChanged := hourChanged || prev.Minute() != now.Minute()
+	secondChanged := minuteChanged || prev.Second() != now.Second()
+
+	if dayChanged {
+		t.fire(TickEvent{Unit: UnitDay, Previous: prevDay, Current: now.YearDay(), Time: now, Rollover: prevYear != now.Year()})
+	}
+	if hourChanged {
+		t.fire(TickEvent{Unit: UnitHour, Previous: prev.Hour(), Current: now.Hour(), Time: now, Rollover: dayChanged})
+	}
+	if minuteChanged {
+		t.fire(TickEvent{Unit: UnitMinute, Previous: prev.Minute(), Current: now.Minute(), Time: now, Rollover: hourChanged})
+	}
+	if secondChanged {
+		t.fire(TickEvent{Unit: UnitSecond, Previous: prev.Second(), Current: now.Second(), Time: now, Rollover: minuteChanged})
+	}
+}
+
+func (t *TickData) fire(e TickEvent) {
+	for _, h := range t.subscribers.units[e.Unit] {
+		h(e)
+	}
+	if e.Rollover {
+		for _, h := range t.subscribers.rollover {
+			h(e)
+		}
+	}
+}
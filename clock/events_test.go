@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recordEvents subscribes to every unit and to rollovers of tick. Unit
+// handlers write "hour 23->0", followed by "rolled" when Rollover is set,
+// the OnRollover handler writes "rollover hour 23->0".
+func recordEvents(t *testing.T, tick *TickData) *[]string {
+	var events []string
+	record := func(kind string) TickHandler {
+		return func(e TickEvent) {
+			if !e.Time.Equal(tick.Time) {
+				t.Errorf("%s %v event at %v, want the tick time %v", kind, e.Unit, e.Time, tick.Time)
+			}
+			line := fmt.Sprintf("%s %d->%d", kind, e.Previous, e.Current)
+			if kind != e.Unit.String() {
+				line = fmt.Sprintf("%s %v %d->%d", kind, e.Unit, e.Previous, e.Current)
+			} else if e.Rollover {
+				line += " rolled"
+			}
+			events = append(events, line)
+		}
+	}
+	tick.OnSecond(record("second"))
+	tick.OnMinute(record("minute"))
+	tick.OnHour(record("hour"))
+	tick.OnDay(record("day"))
+	tick.OnRollover(record("rollover"))
+	return &events
+}
+
+func TestTickEventsNewYear(t *testing.T) {
+	for _, zone := range []string{"UTC", "Pacific/Auckland"} {
+		loc := mustZone(t, zone)
+		source := NewFixedClock(time.Date(2026, 12, 31, 23, 59, 58, 0, loc))
+		tick := NewTickData(source, loc)
+		events := recordEvents(t, tick)
+
+		steps := []struct {
+			step time.Duration
+			want []string
+		}{
+			{time.Second, []string{"second 58->59"}},
+			{time.Second, []string{
+				"day 365->1 rolled", "rollover day 365->1",
+				"hour 23->0 rolled", "rollover hour 23->0",
+				"minute 59->0 rolled", "rollover minute 59->0",
+				"second 59->0 rolled", "rollover second 59->0",
+			}},
+			{500 * time.Millisecond, nil},
+			{500 * time.Millisecond, []string{"second 0->1"}},
+			{59 * time.Second, []string{"minute 0->1", "second 1->0 rolled", "rollover second 1->0"}},
+		}
+		for _, s := range steps {
+			*events = nil
+			source.Advance(s.step)
+			tick.Update()
+			if !slices.Equal(*events, s.want) {
+				t.Errorf("%s at %v: got %q, want %q", zone, tick.Time.Format(time.DateTime), *events, s.want)
+			}
+		}
+	}
+}
+
+func TestTickEventsFirstUpdate(t *testing.T) {
+	source := NewFixedClock(time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC))
+	tick := &TickData{Location: time.UTC, source: source}
+	events := recordEvents(t, tick)
+
+	// nothing to compare the first instant with
+	tick.Update()
+	if len(*events) != 0 {
+		t.Errorf("first update: got %q, want no events", *events)
+	}
+}
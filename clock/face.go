@@ -0,0 +1,115 @@
+package clock
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Face is a clock face that can be placed in a window and driven by ticks
+type Face interface {
+	CanvasObject() fyne.CanvasObject
+	Update(t *TickData)
+	MinSize() fyne.Size
+	Name() string
+}
+
+// FaceOptions carries the settings shared by every face constructor
+type FaceOptions struct {
+	Source   TimeSource
+	Location *time.Location
+	Palette  Palette
+	Mode24hr bool
+	Sweep    bool // smooth second hand, ignored by faces without hands
+	Size     int  // smallest radius or digit width, 0 for the face default
+	Digital  DigitalOptions
+}
+
+// FaceConstructor builds a face from options, registered with RegisterFace
+type FaceConstructor func(opts FaceOptions) (Face, error)
+
+// Names of the built in faces
+const (
+	FaceAnalog  = "analog"
+	FaceDigital = "digital"
+	FaceRing    = "ring"
+)
+
+// Default geometry of the built in faces, faces grow from this size to
+// fill the space they are given
+const (
+	faceRadius       = 80
+	faceRingRadius   = 40
+	faceDigitWidth   = 70
+	faceDigitSpacing = 10
+)
+
+var (
+	faceRegistryMu sync.RWMutex
+	faceRegistry   = map[string]FaceConstructor{}
+)
+
+func init() {
+	RegisterFace(FaceAnalog, func(opts FaceOptions) (Face, error) {
+		analog := NewAnalogClock(opts.Source, opts.Location, sizeOr(opts.Size, faceRadius))
+		analog.SetSweep(opts.Sweep)
+		return analog, nil
+	})
+	RegisterFace(FaceDigital, func(opts FaceOptions) (Face, error) {
+		p := opts.Palette
+		digital := NewDigitalClock(opts.Source, opts.Location, opts.Mode24hr, p.On, p.Off, p.Stroke, sizeOr(opts.Size, faceDigitWidth), faceDigitSpacing)
+		if err := digital.SetOptions(opts.Digital); err != nil {
+			return nil, err
+		}
+		return digital, nil
+	})
+	RegisterFace(FaceRing, func(opts FaceOptions) (Face, error) {
+		p := opts.Palette
+		ring := NewRingClock(opts.Source, opts.Location, sizeOr(opts.Size, faceRingRadius), p.SecondColor, p.MinuteColor, p.HourColor, p.Off, p.Stroke)
+		ring.BackFillArcsContainer()
+		return ring, nil
+	})
+}
+
+func sizeOr(size, fallback int) int {
+	if size > 0 {
+		return size
+	}
+	return fallback
+}
+
+// RegisterFace makes a face available to NewFace under name,
+// replacing any face already registered with that name
+func RegisterFace(name string, ctor FaceConstructor) {
+	faceRegistryMu.Lock()
+	defer faceRegistryMu.Unlock()
+	faceRegistry[name] = ctor
+}
+
+// NewFace creates the face registered under name
+func NewFace(name string, opts FaceOptions) (Face, error) {
+	faceRegistryMu.RLock()
+	ctor, ok := faceRegistry[name]
+	faceRegistryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown clock face %q, have %v", name, FaceNames())
+	}
+	return ctor(opts)
+}
+
+// FaceNames lists the registered faces in alphabetical order
+func FaceNames() []string {
+	faceRegistryMu.RLock()
+	defer faceRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(faceRegistry))
+	for name := range faceRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
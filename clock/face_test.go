@@ -0,0 +1,89 @@
+package clock
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+// testFace is a face that only knows its name
+type testFace struct{ name string }
+
+func (f testFace) CanvasObject() fyne.CanvasObject { return nil }
+func (f testFace) Update(*TickData)                {}
+func (f testFace) MinSize() fyne.Size              { return fyne.Size{} }
+func (f testFace) Name() string                    { return f.name }
+
+// namedFace is a constructor of testFaces called name
+func namedFace(name string) FaceConstructor {
+	return func(FaceOptions) (Face, error) { return testFace{name}, nil }
+}
+
+// registerTestFace registers ctor as name until the test ends
+func registerTestFace(t *testing.T, name string, ctor FaceConstructor) {
+	RegisterFace(name, ctor)
+	t.Cleanup(func() {
+		faceRegistryMu.Lock()
+		defer faceRegistryMu.Unlock()
+		delete(faceRegistry, name)
+	})
+}
+
+func TestFaceNames(t *testing.T) {
+	if got, want := FaceNames(), []string{FaceAnalog, FaceDigital, FaceRing}; !slices.Equal(got, want) {
+		t.Errorf("built in faces: got %q, want %q", got, want)
+	}
+
+	registerTestFace(t, "zodiac", namedFace("zodiac"))
+	registerTestFace(t, "binary", namedFace("binary"))
+	if got, want := FaceNames(), []string{FaceAnalog, "binary", FaceDigital, FaceRing, "zodiac"}; !slices.Equal(got, want) {
+		t.Errorf("with test faces: got %q, want %q", got, want)
+	}
+}
+
+func TestRegisterFaceDuplicate(t *testing.T) {
+	registerTestFace(t, "binary", namedFace("first"))
+	registerTestFace(t, "binary", namedFace("second"))
+
+	face, err := NewFace("binary", FaceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if face.Name() != "second" {
+		t.Errorf("got face %q, want the second registration to replace the first", face.Name())
+	}
+
+	count := 0
+	for _, name := range FaceNames() {
+		if name == "binary" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("binary listed %d times, want once", count)
+	}
+}
+
+func TestNewFaceErrors(t *testing.T) {
+	test.NewTempApp(t)
+
+	_, err := NewFace("sundial", FaceOptions{})
+	if err == nil || !strings.Contains(err.Error(), `"sundial"`) || !strings.Contains(err.Error(), FaceDigital) {
+		t.Errorf("unknown face: got %v, want an error naming sundial and the known faces", err)
+	}
+
+	broken := errors.New("broken")
+	registerTestFace(t, "broken", func(FaceOptions) (Face, error) { return nil, broken })
+	if _, err := NewFace("broken", FaceOptions{}); !errors.Is(err, broken) {
+		t.Errorf("failing constructor: got %v, want its error", err)
+	}
+
+	// the digital face passes on geometry SetOptions rejects
+	if _, err := NewFace(FaceDigital, FaceOptions{Palette: DefaultPalette(), Digital: DigitalOptions{Slant: 1}}); err == nil {
+		t.Error("digital with slant 1: got no error")
+	}
+}
@@ -0,0 +1,245 @@
+package clock
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"golang.org/x/image/vector"
+)
+
+// SegmentStyle is the outline of each seven segment bar
+type SegmentStyle int
+
+const (
+	SegmentRectangle SegmentStyle = iota
+	SegmentHexagon                // pointed ends that meet at the corners, like an LED display
+	SegmentBevel                  // rectangles with the corners cut off
+)
+
+var segmentStyleNames = [...]string{"rectangle", "hexagon", "bevel"}
+
+func (s SegmentStyle) String() string {
+	if s < 0 || int(s) >= len(segmentStyleNames) {
+		return fmt.Sprintf("SegmentStyle(%d)", int(s))
+	}
+	return segmentStyleNames[s]
+}
+
+// ParseSegmentStyle reads rectangle, hexagon or bevel, empty is rectangle
+func ParseSegmentStyle(name string) (SegmentStyle, error) {
+	if name == "" {
+		return SegmentRectangle, nil
+	}
+	for i, n := range segmentStyleNames {
+		if strings.EqualFold(name, n) {
+			return SegmentStyle(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown segment style %q, want one of %s", name, strings.Join(segmentStyleNames[:], ", "))
+}
+
+// DigitGeometry sizes the segments of a seven segment digit
+type DigitGeometry struct {
+	Width, Height float32
+	Thickness     float32 // segment thickness as a fraction of Width
+	Slant         float32 // how far the top leans right as a fraction of Height, negative leans left
+	Style         SegmentStyle
+}
+
+// Default digit proportions, the 70x110 digit with 10 wide segments
+const (
+	defaultDigitAspect    = 110.0 / 70.0
+	defaultDigitThickness = 1.0 / 7.0
+	maxDigitThickness     = 0.25
+	maxDigitSlant         = 0.5
+)
+
+// NewDigitGeometry is an upright digit of the given width with the
+// default proportions
+func NewDigitGeometry(width float32) DigitGeometry {
+	return DigitGeometry{
+		Width:     width,
+		Height:    width * defaultDigitAspect,
+		Thickness: defaultDigitThickness,
+	}
+}
+
+// Validate reports geometry that cannot be drawn
+func (g DigitGeometry) Validate() error {
+	switch {
+	case g.Width <= 0 || g.Height <= 0:
+		return fmt.Errorf("digit size must be positive, got %gx%g", g.Width, g.Height)
+	case g.Thickness <= 0 || g.Thickness > maxDigitThickness:
+		return fmt.Errorf("segment thickness must be above 0 and at most %g, got %g", maxDigitThickness, g.Thickness)
+	case g.Slant < -maxDigitSlant || g.Slant > maxDigitSlant:
+		return fmt.Errorf("slant must be between %g and %g, got %g", -maxDigitSlant, maxDigitSlant, g.Slant)
+	case 3*g.thickness() >= g.Height:
+		return errors.New("segments are too thick for the digit height")
+	}
+	return nil
+}
+
+func (g DigitGeometry) thickness() float32 {
+	return g.Width * g.Thickness
+}
+
+// slantWidth is the design width including the lean of the top
+func (g DigitGeometry) slantWidth() float32 {
+	if g.Slant < 0 {
+		return g.Width - g.Slant*g.Height
+	}
+	return g.Width + g.Slant*g.Height
+}
+
+// skew moves a point at height y by the lean, keeping the digit at x >= 0
+func (g DigitGeometry) skew(p fyne.Position) fyne.Position {
+	shift := g.Slant * (g.Height - p.Y)
+	if g.Slant < 0 {
+		shift -= g.Slant * g.Height
+	}
+	return fyne.NewPos(p.X+shift, p.Y)
+}
+
+// outlined reports whether segments need a polygon rather than a rectangle
+func (g DigitGeometry) outlined() bool {
+	return g.Style != SegmentRectangle || g.Slant != 0
+}
+
+// segmentShape is the bounding box of a segment and, for outlined
+// geometry, its outline relative to the box
+type segmentShape struct {
+	rect    SegmentRect
+	outline []fyne.Position
+}
+
+// shapes lays the segments out in a, b, c, d, e, f, g order
+func (g DigitGeometry) shapes() [7]segmentShape {
+	w, h, t := g.Width, g.Height, g.thickness()
+	v := (h - 3*t) / 2 // length of a vertical segment
+
+	boxes := [7]SegmentRect{
+		{Position: fyne.NewPos(t, 0), Size: fyne.NewSize(w-2*t, t)},   // a
+		{Position: fyne.NewPos(w-t, t), Size: fyne.NewSize(t, v)},     // b
+		{Position: fyne.NewPos(w-t, 2*t+v), Size: fyne.NewSize(t, v)}, // c
+		{Position: fyne.NewPos(t, h-t), Size: fyne.NewSize(w-2*t, t)}, // d
+		{Position: fyne.NewPos(0, 2*t+v), Size: fyne.NewSize(t, v)},   // e
+		{Position: fyne.NewPos(0, t), Size: fyne.NewSize(t, v)},       // f
+		{Position: fyne.NewPos(t, t+v), Size: fyne.NewSize(w-2*t, t)}, // g
+	}
+
+	var shapes [7]segmentShape
+	for i, box := range boxes {
+		if !g.outlined() {
+			shapes[i] = segmentShape{rect: box}
+			continue
+		}
+
+		var points []fyne.Position
+		switch g.Style {
+		case SegmentHexagon:
+			points = hexagonOutline(box, t/10, i == 0 || i == 3 || i == 6)
+		case SegmentBevel:
+			points = bevelOutline(box)
+		default:
+			x, y, bw, bh := box.Position.X, box.Position.Y, box.Size.Width, box.Size.Height
+			points = []fyne.Position{{X: x, Y: y}, {X: x + bw, Y: y}, {X: x + bw, Y: y + bh}, {X: x, Y: y + bh}}
+		}
+		shapes[i] = outlineShape(g, points)
+	}
+	return shapes
+}
+
+// rects is the bounding box of every segment
+func (g DigitGeometry) rects() [7]SegmentRect {
+	var rects [7]SegmentRect
+	for i, shape := range g.shapes() {
+		rects[i] = shape.rect
+	}
+	return rects
+}
+
+// pointRect is the decimal point, a slightly smaller square than the
+// segment thickness sitting on the baseline in the gap after the digit
+func (g DigitGeometry) pointRect() SegmentRect {
+	t := g.thickness()
+	size := t * 0.8
+	return SegmentRect{
+		Position: g.skew(fyne.NewPos(g.Width+t*0.1, g.Height-size)),
+		Size:     fyne.NewSize(size, size),
+	}
+}
+
+// hexagonOutline stretches a box to pointed ends that reach into the
+// corners of the digit, leaving gap between neighbouring segments
+func hexagonOutline(box SegmentRect, gap float32, horizontal bool) []fyne.Position {
+	x, y, w, h := box.Position.X, box.Position.Y, box.Size.Width, box.Size.Height
+	if horizontal {
+		x0, x1, yc := x-h/2+gap, x+w+h/2-gap, y+h/2
+		return []fyne.Position{
+			{X: x0, Y: yc}, {X: x0 + h/2, Y: y}, {X: x1 - h/2, Y: y},
+			{X: x1, Y: yc}, {X: x1 - h/2, Y: y + h}, {X: x0 + h/2, Y: y + h},
+		}
+	}
+	y0, y1, xc := y-w/2+gap, y+h+w/2-gap, x+w/2
+	return []fyne.Position{
+		{X: xc, Y: y0}, {X: x + w, Y: y0 + w/2}, {X: x + w, Y: y1 - w/2},
+		{X: xc, Y: y1}, {X: x, Y: y1 - w/2}, {X: x, Y: y0 + w/2},
+	}
+}
+
+// bevelOutline cuts a third of the thickness off each corner of a box
+func bevelOutline(box SegmentRect) []fyne.Position {
+	x, y, w, h := box.Position.X, box.Position.Y, box.Size.Width, box.Size.Height
+	c := min(w, h) / 3
+	return []fyne.Position{
+		{X: x + c, Y: y}, {X: x + w - c, Y: y}, {X: x + w, Y: y + c}, {X: x + w, Y: y + h - c},
+		{X: x + w - c, Y: y + h}, {X: x + c, Y: y + h}, {X: x, Y: y + h - c}, {X: x, Y: y + c},
+	}
+}
+
+// outlineShape slants points and makes them relative to their bounding box
+func outlineShape(g DigitGeometry, points []fyne.Position) segmentShape {
+	lo := fyne.NewPos(g.slantWidth(), g.Height)
+	hi := fyne.NewPos(0, 0)
+	for i, p := range points {
+		p = g.skew(p)
+		points[i] = p
+		lo = fyne.NewPos(min(lo.X, p.X), min(lo.Y, p.Y))
+		hi = fyne.NewPos(max(hi.X, p.X), max(hi.Y, p.Y))
+	}
+	for i, p := range points {
+		points[i] = p.Subtract(lo)
+	}
+	return segmentShape{
+		rect:    SegmentRect{Position: lo, Size: fyne.NewSize(hi.X-lo.X, hi.Y-lo.Y)},
+		outline: points,
+	}
+}
+
+// fillOutline returns a raster generator that fills outline, drawn in a
+// box of size design units, with fill at whatever pixel size it is given
+func fillOutline(outline []fyne.Position, size fyne.Size, fill color.Color) func(w, h int) image.Image {
+	return func(w, h int) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		if w == 0 || h == 0 || size.Width == 0 || size.Height == 0 {
+			return img
+		}
+
+		sx, sy := float32(w)/size.Width, float32(h)/size.Height
+		r := vector.NewRasterizer(w, h)
+		for i, p := range outline {
+			if i == 0 {
+				r.MoveTo(p.X*sx, p.Y*sy)
+			} else {
+				r.LineTo(p.X*sx, p.Y*sy)
+			}
+		}
+		r.ClosePath()
+		r.Draw(img, img.Bounds(), image.NewUniform(fill), image.Point{})
+		return img
+	}
+}
@@ -0,0 +1,89 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigitGeometryValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(g *DigitGeometry)
+		ok   bool
+	}{
+		{"default", func(g *DigitGeometry) {}, true},
+		{"zero width", func(g *DigitGeometry) { g.Width = 0 }, false},
+		{"negative height", func(g *DigitGeometry) { g.Height = -1 }, false},
+		{"no thickness", func(g *DigitGeometry) { g.Thickness = 0 }, false},
+		{"thickest", func(g *DigitGeometry) { g.Thickness = maxDigitThickness }, true},
+		{"too thick", func(g *DigitGeometry) { g.Thickness = maxDigitThickness + 0.01 }, false},
+		{"most slant", func(g *DigitGeometry) { g.Slant = maxDigitSlant }, true},
+		{"most left slant", func(g *DigitGeometry) { g.Slant = -maxDigitSlant }, true},
+		{"too much slant", func(g *DigitGeometry) { g.Slant = maxDigitSlant + 0.01 }, false},
+		{"too much left slant", func(g *DigitGeometry) { g.Slant = -maxDigitSlant - 0.01 }, false},
+		// three segments 17.5 high leave nothing for the verticals
+		{"too short", func(g *DigitGeometry) { g.Thickness, g.Height = maxDigitThickness, 52.5 }, false},
+		{"square", func(g *DigitGeometry) { g.Height = g.Width }, true},
+	}
+	for _, tt := range tests {
+		g := NewDigitGeometry(70)
+		tt.edit(&g)
+		if err := g.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: got %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestDigitGeometryInBounds(t *testing.T) {
+	const eps = 1e-3
+	for _, style := range []SegmentStyle{SegmentRectangle, SegmentHexagon, SegmentBevel} {
+		for _, slant := range []float32{-maxDigitSlant, -0.1, 0, 0.1, maxDigitSlant} {
+			g := NewDigitGeometry(70)
+			g.Style, g.Slant, g.Thickness = style, slant, maxDigitThickness
+			if err := g.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, shape := range g.shapes() {
+				r := shape.rect
+				if r.Position.X < -eps || r.Position.Y < -eps ||
+					r.Position.X+r.Size.Width > g.slantWidth()+eps || r.Position.Y+r.Size.Height > g.Height+eps {
+					t.Errorf("%v slant %g: segment %c at %v outside the %gx%g digit", style, slant, 'a'+i, r, g.slantWidth(), g.Height)
+				}
+				if g.outlined() != (shape.outline != nil) {
+					t.Errorf("%v slant %g: segment %c outline %v", style, slant, 'a'+i, shape.outline)
+				}
+				for _, p := range shape.outline {
+					if p.X < -eps || p.Y < -eps || p.X > r.Size.Width+eps || p.Y > r.Size.Height+eps {
+						t.Errorf("%v slant %g: segment %c point %v outside its %v box", style, slant, 'a'+i, p, r.Size)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDigitalClockSetOptionsGeometry(t *testing.T) {
+	d, _ := newTestDigitalClock(t, time.Date(2024, 10, 18, 9, 59, 0, 0, time.UTC))
+
+	if err := d.SetOptions(DigitalOptions{Aspect: 2, Slant: 0.1}); err != nil {
+		t.Fatal(err)
+	}
+	if g := d.SevenSegmentDisplay.Geometry; g.Height != 140 || g.Slant != 0.1 || d.digitHeight() != 140 {
+		t.Errorf("aspect 2: got %gx%g slant %g", g.Width, g.Height, g.Slant)
+	}
+
+	for _, opts := range []DigitalOptions{
+		{Aspect: -1},
+		{Thickness: 0.3},
+		{Slant: 0.6},
+		{Aspect: 0.5, Thickness: 0.25},
+	} {
+		if err := d.SetOptions(opts); err == nil {
+			t.Errorf("%+v: got no error", opts)
+		}
+		if d.SevenSegmentDisplay.Geometry.Height != 140 || d.opts.Aspect != 2 {
+			t.Errorf("%+v: changed the display to %+v", opts, d.SevenSegmentDisplay.Geometry)
+		}
+	}
+}
@@ -1,348 +1,269 @@
 package clock
 
 import (
-	"fmt"
 	"image/color"
 	"math"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"temp.com/go-clock/utils"
 )
 
+// RingClock is a widget showing seconds, minutes and hours as three rings
+// that fill up as time passes. The rings scale to fit the space given.
 type RingClock struct {
-	ClockFace                *fyne.Container   // Collection of all clock containers
-	clocksContainer          []*fyne.Container //Collection ring of each ring, label and arcs grouping i.e. HH, MM, SS
-	arcContainers            []*fyne.Container //Contains collection of arcs for filling the ring
+	widget.BaseWidget
+	ZoneLabel                *canvas.Text
+	tick                     *TickData
 	clockRings               []ClockRing
-	cx, cy                   int
-	radius                   int
-	thickness                int
-	spacing                  int
+	arcAngles                [][]float64 // angle of every filled arc, per ring
+	minRadius                int
 	strokeColor              color.Color
 	numRings                 int
-	offsetXarr               []int
-	arcStrokeWidth           []float32
-	timeAngles               []float64
 	ringColorDimFactor       float32
 	labelScaleFactorOfRadius float32
 	arcOverShoot             int
-	arcResolution            int
-	arcNumResolutionLines    []int
 }
 
 // Specifc Data to each ring in th=e clock face
 type ClockRing struct {
 	Name       string
 	epochLabel string
-	X, Y       int
 	onColor    color.Color
 	offColor   color.Color
 	labelColor color.Color
+	unit       TimeUnit
+	arcDegrees float64 // angle covered by one arc
 }
 
-func NewRingClock(cx, cy, radius int, secondColor, MinuteColor, HourColor, offColor, strokeColor color.Color) *RingClock {
-	time := NewTickData()
+// Ring proportions as fractions of the ring radius
+const (
+	ringThicknessFactor = 0.5   // 40px on an 80px ring
+	ringSpacingFactor   = 0.125 // gap between rings
+	ringMaskFactor      = 0.125 // width of the mask hiding arc overshoot
+	ringLabelGap        = 5
+)
+
+// NewRingClock creates a ring clock widget, radius is the smallest ring
+// radius it will shrink to
+func NewRingClock(source TimeSource, loc *time.Location, radius int, secondColor, MinuteColor, HourColor, offColor, strokeColor color.Color) *RingClock {
+	time := NewTickData(source, loc)
 
-	const thickness, spacing = 40, 10
 	const labelScaleFactorOfRadius = 0.3
 	const ringColorDimFactor = 0.3
 	const numRings = 3
 	const arcOverShoot = 1
-	const arcResolution = 1
-
-	var arcNumResolutionLines []int
-	var clocksContainers []*fyne.Container
-	var arcContainers []*fyne.Container
-
-	hourAngle, minuteAngle, secondAngle := getClockHandAngles(time)
-	timeAngles := time.GetClockAngles()
-
-	secondStrokeWidth := float32(radius) * (6 * math.Pi / 180)
-	minuteStrokeWidth := secondStrokeWidth
-	hourStrokeWidth := float32(radius) * (math.Pi / 6)
-
-	arcStrokeWidth := []float32{secondStrokeWidth, minuteStrokeWidth, hourStrokeWidth}
-
-	for _, width := range arcStrokeWidth {
-		numLines := int(float32(math.Round(float64(width) / float64(arcResolution))))
-		arcNumResolutionLines = append(arcNumResolutionLines, numLines)
-	}
-
-	offsetXarr := []int{}
-	for i := 0; i < numRings; i++ {
-		offsetX := i * ((radius * 2) + spacing)
-		offsetXarr = append(offsetXarr, offsetX)
-	}
-
-	secondX, secondY := getClockHandPosition(offsetXarr[0], cy, radius, secondAngle)
-	minuteX, minuteY := getClockHandPosition(offsetXarr[1], cy, radius, minuteAngle)
-	hourX, hourY := getClockHandPosition(offsetXarr[2], cy, radius, hourAngle)
 
 	clockRings := []ClockRing{
-		{"Seconds", "SS", secondX, secondY, secondColor, utils.DimColor(secondColor, ringColorDimFactor), secondColor},
-		{"Minutes", "MM", minuteX, minuteY, MinuteColor, utils.DimColor(MinuteColor, ringColorDimFactor), MinuteColor},
-		{"Hours", "HH", hourX, hourY, HourColor, utils.DimColor(HourColor, ringColorDimFactor), HourColor},
+		{"Seconds", "SS", secondColor, utils.DimColor(secondColor, ringColorDimFactor), secondColor, UnitSecond, 6},
+		{"Minutes", "MM", MinuteColor, utils.DimColor(MinuteColor, ringColorDimFactor), MinuteColor, UnitMinute, 6},
+		{"Hours", "HH", HourColor, utils.DimColor(HourColor, ringColorDimFactor), HourColor, UnitHour, 30},
 	}
 
-	//Construnct each temporal clock
-	for i, r := range clockRings {
-		ring := drawRing(cx+offsetXarr[i], cy, radius, thickness, theme.Color(theme.ColorNameBackground), clockRings[i].offColor)
-		ringsContainers := container.NewWithoutLayout()
-		ringsContainers.Add(ring)
-
-		label := canvas.NewText(r.Name, clockRings[i].labelColor)
-		label.TextSize = float32(radius) * labelScaleFactorOfRadius
-		label.Alignment = fyne.TextAlignCenter
-		label.TextStyle = fyne.TextStyle{Bold: true}
-		label.Move(fyne.NewPos(float32(cx+offsetXarr[i]), float32(cy-(int(label.MinSize().Height)/2))))
-
-		arcContainer := container.NewWithoutLayout()
-		arcContainers = append(arcContainers, arcContainer)
-
-		clockContainer := container.NewWithoutLayout(ring, label, arcContainer)
-
-		clocksContainers = append(clocksContainers, clockContainer)
-	}
-
-	canvasObjects := make([]fyne.CanvasObject, len(clocksContainers))
-	for i, c := range clocksContainers {
-		canvasObjects[i] = c
-	}
-
-	ClockFace := container.NewWithoutLayout(canvasObjects...)
-
-	return &RingClock{
-		ClockFace:                ClockFace,
-		clocksContainer:          clocksContainers,
-		arcContainers:            arcContainers,
-		cx:                       cx,
-		cy:                       cy,
-		radius:                   radius,
-		thickness:                thickness,
-		spacing:                  spacing,
+	r := &RingClock{
+		ZoneLabel:                drawZoneLabel(time.ZoneName()),
+		tick:                     time,
+		clockRings:               clockRings,
+		arcAngles:                make([][]float64, numRings),
+		minRadius:                radius,
 		strokeColor:              strokeColor,
-		timeAngles:               timeAngles,
 		numRings:                 numRings,
-		offsetXarr:               offsetXarr,
-		arcStrokeWidth:           arcStrokeWidth,
-		clockRings:               clockRings,
 		ringColorDimFactor:       ringColorDimFactor,
 		labelScaleFactorOfRadius: labelScaleFactorOfRadius,
 		arcOverShoot:             arcOverShoot,
-		arcResolution:            arcResolution,
-		arcNumResolutionLines:    arcNumResolutionLines,
 	}
-}
-
-func drawRing(cx, cy, radius, thickness int, innerColor, outerColor color.Color) *fyne.Container {
 
-	outerRing := canvas.NewCircle(outerColor)
-	innerRing := canvas.NewCircle(innerColor)
+	time.OnSecond(r.onTick)
+	time.OnMinute(r.onTick)
+	time.OnHour(r.onTick)
 
-	outerRing.Resize(fyne.NewSize(float32(radius*2), float32(radius*2)))
-	outerRing.Move(fyne.NewPos(float32(cx-radius), float32(cy-radius)))
-
-	innerRing.Resize(fyne.NewSize(float32(radius*2-thickness), float32(radius*2-thickness)))
-	innerRing.Move(fyne.NewPos(float32(cx-radius+(thickness/2)), float32(cy-radius+(thickness/2))))
+	r.ExtendBaseWidget(r)
+	return r
+}
 
-	ring := container.NewWithoutLayout(
-		outerRing,
-		innerRing,
-	)
-	return ring
+// CanvasObject returns the widget itself
+func (r *RingClock) CanvasObject() fyne.CanvasObject {
+	return r
 }
 
-// Draw singular arc using fyne.canvas.Line
-func (r *RingClock) drawArc(cx, cy, x, y int, stroke float32, arcColor color.Color) *canvas.Line {
+func (r *RingClock) Name() string {
+	return FaceRing
+}
 
-	arc := canvas.NewLine(arcColor)
-	arc.StrokeWidth = float32(r.arcResolution)
-	arc.Position1 = fyne.NewPos(float32(cx), float32(cy))
-	arc.Position2 = fyne.NewPos(float32(x), float32(y))
-	arc.StrokeWidth = stroke
+func (r *RingClock) CreateRenderer() fyne.WidgetRenderer {
+	renderer := &ringRenderer{clock: r}
+	for _, ring := range r.clockRings {
+		label := canvas.NewText(ring.Name, ring.labelColor)
+		label.Alignment = fyne.TextAlignCenter
+		label.TextStyle = fyne.TextStyle{Bold: true}
 
-	return arc
-}
+		mask := canvas.NewCircle(color.Transparent)
 
-func (r *RingClock) drawArcsToResolution(cx, cy, x, y int, stroke float32, arcColor color.Color) []*canvas.Line {
-	var linesArr []*canvas.Line
-	for i := range r.clockRings {
-		numLines := r.arcNumResolutionLines[i]
-
-		for j := 0; j < numLines; j++ {
-			arc := canvas.NewLine(arcColor)
-			arc.StrokeWidth = stroke
-			arc.Position1 = fyne.NewPos(float32(cx), float32(cy))
-			arc.Position2 = fyne.NewPos(float32(x), float32(y))
-			linesArr = append(linesArr, arc)
-		}
+		renderer.rings = append(renderer.rings, &ringObjects{
+			outer: canvas.NewCircle(ring.offColor),
+			inner: canvas.NewCircle(theme.Color(theme.ColorNameBackground)),
+			mask:  mask,
+			label: label,
+		})
 	}
-	return linesArr
+	renderer.syncArcs()
+	return renderer
 }
 
 // Back fill all arcs to current time
 func (r *RingClock) BackFillArcsContainer() {
-	// startPointAngle := 0
-	endPointAngleArr := r.timeAngles
-
-	numBackFillArcs := []int{
-		int(endPointAngleArr[0] / 6),  // seconds
-		int(endPointAngleArr[1] / 6),  // minutes
-		int(endPointAngleArr[2] / 30), // hours
-	}
-
-	angleStep := []float64{
-		6,  // seconds step
-		6,  // minutes step
-		30, // hours step
-	}
+	endPointAngleArr := r.tick.GetClockAngles()
 
 	for i, ring := range r.clockRings {
-		cx := r.cx + r.offsetXarr[i]
-		count := numBackFillArcs[i]
-		stepDegrees := angleStep[i]
+		count := int(endPointAngleArr[i] / ring.arcDegrees)
 
+		r.arcAngles[i] = r.arcAngles[i][:0]
 		for step := 0; step <= count; step++ {
-			angle := float64(step) * stepDegrees
-			x, y := getClockHandPosition(cx, r.cy, r.radius+r.arcOverShoot, angle)
-			// arcs := r.drawArcsToResolution(cx, r.cy, x, y, r.arcStrokeWidth[i], ring.onColor)
-			// for _, arc := range arcs {
-			// 	r.arcContainers[i].Add(arc)
-			// }
+			r.arcAngles[i] = append(r.arcAngles[i], float64(step)*ring.arcDegrees)
+		}
+	}
+	r.Refresh()
+}
 
-			arc := r.drawArc(cx, r.cy, x, y, r.arcStrokeWidth[i], ring.onColor)
-			r.arcContainers[i].Add(arc)
+// onTick adds an arc to the ring showing e.Unit, emptying the ring first
+// when it has gone all the way round
+func (r *RingClock) onTick(e TickEvent) {
+	for i, ring := range r.clockRings {
+		if ring.unit != e.Unit {
+			continue
+		}
 
+		// the hour ring shows 12 hours so it also empties at noon
+		if e.Rollover || (e.Unit == UnitHour && e.Current%12 == 0) {
+			r.arcAngles[i] = r.arcAngles[i][:0]
 		}
-		r.refreshStaticClockItems(i)
 
+		r.arcAngles[i] = append(r.arcAngles[i], r.tick.GetClockAngles()[i])
 	}
-
 }
 
-// Refreshes the clock static clock components that are over written by drawing the arcs
-func (r *RingClock) refreshStaticClockItems(i int) {
-	c := r.clocksContainer[i]
+// Update syncs the ring's tick, which adds arcs through onTick
+func (r *RingClock) Update(t *TickData) {
+	r.tick.Sync(t)
 
-	cleaned := []fyne.CanvasObject{}
-	for _, obj := range c.Objects {
-		switch obj.(type) {
-		case *canvas.Circle, *canvas.Text:
-		default:
-			cleaned = append(cleaned, obj)
-		}
+	if zone := r.tick.ZoneName(); r.ZoneLabel.Text != zone {
+		r.ZoneLabel.Text = zone
+		r.ZoneLabel.Refresh()
 	}
-	c.Objects = cleaned
-
-	cx := r.cx + r.offsetXarr[i]
-
-	innerCircle := canvas.NewCircle(theme.Color(theme.ColorNameBackground))
-	innerCircle.Resize(fyne.NewSize(float32(r.radius*2-r.thickness), float32(r.radius*2-r.thickness)))
-	innerCircle.Move(fyne.NewPos(
-		float32(cx-r.radius+(r.thickness/2)),
-		float32(r.cy-r.radius+(r.thickness/2)),
-	))
-
-	label := canvas.NewText(r.clockRings[i].Name, r.clockRings[i].labelColor)
-	label.TextSize = float32(r.radius) * r.labelScaleFactorOfRadius
-	label.Alignment = fyne.TextAlignCenter
-	label.TextStyle = fyne.TextStyle{Bold: true}
-	label.Move(fyne.NewPos(
-		float32(cx),
-		float32(r.cy-(int(label.MinSize().Height)/2)),
-	))
-
-	// outer ring mask to cover overshoot from arcs to give perfect circle finish
-	const maskStrokeWidth = float32(10)
-	mask := canvas.NewCircle(color.Transparent)
-	mask.StrokeWidth = maskStrokeWidth
-	mask.StrokeColor = theme.Color(theme.ColorNameBackground)
-	mask.Resize(fyne.NewSize(float32(r.radius*2+int(maskStrokeWidth)), float32(r.radius*2+int(maskStrokeWidth))))
-	mask.Move(fyne.NewPos(float32(cx-r.radius-int(maskStrokeWidth/2)), float32(r.cy-r.radius-int(maskStrokeWidth/2))))
-
-	r.clocksContainer[i].Add(mask)
-	c.Add(innerCircle)
-	c.Add(label)
-	c.Add(mask)
-	c.Refresh()
 
+	r.Refresh()
 }
 
-func (r *RingClock) Update(t *TickData) {
-	angleArr := t.GetClockAngles()
+// canvas objects making up a single ring, drawn in this order so the
+// inner circle and mask trim the arc lines into a ring segment
+type ringObjects struct {
+	outer *canvas.Circle
+	arcs  []*canvas.Line
+	inner *canvas.Circle
+	label *canvas.Text
+	mask  *canvas.Circle
+}
 
-	for i, ring := range r.clockRings {
-		var arc *canvas.Line
-		cx := r.cx + r.offsetXarr[i]
-		x, y := getClockHandPosition(cx, r.cy, r.radius+r.arcOverShoot, angleArr[i])
-
-		// Reset arc container based on ring type
-		if ring.Name == "Seconds" && t.Second%60 == 0 {
-			r.arcContainers[i].Objects = nil
-			r.arcContainers[i].Refresh()
-		}
-		if ring.Name == "Minutes" && t.Minute%60 == 0 {
-			r.arcContainers[i].Objects = nil
-			r.arcContainers[i].Refresh()
-		}
-		if ring.Name == "Hours" && t.Hour12%12 == 0 {
-			r.arcContainers[i].Objects = nil
-			r.arcContainers[i].Refresh()
-		}
-		// Draw if tick detected
-		if ring.Name == "Seconds" && t.SecondChanged() {
-			arc = r.drawArc(cx, r.cy, x, y, r.arcStrokeWidth[i], ring.onColor)
-		}
-		if ring.Name == "Minutes" && t.MinuteChanged() {
-			arc = r.drawArc(cx, r.cy, x, y, r.arcStrokeWidth[i], ring.onColor)
-		}
-		if ring.Name == "Hours" && t.HourChanged() {
-			arc = r.drawArc(cx, r.cy, x, y, r.arcStrokeWidth[i], ring.onColor)
-		}
+type ringRenderer struct {
+	clock   *RingClock
+	rings   []*ringObjects
+	objects []fyne.CanvasObject
+}
 
-		if arc != nil {
-			r.arcContainers[i].Add(arc)
+// syncArcs makes sure there is one line per filled arc and rebuilds the
+// object list in drawing order
+func (r *ringRenderer) syncArcs() {
+	r.objects = []fyne.CanvasObject{}
+	for i, ring := range r.rings {
+		angles := r.clock.arcAngles[i]
+		for len(ring.arcs) < len(angles) {
+			ring.arcs = append(ring.arcs, canvas.NewLine(r.clock.clockRings[i].onColor))
 		}
+		ring.arcs = ring.arcs[:len(angles)]
 
-		r.refreshStaticClockItems(i)
+		r.objects = append(r.objects, ring.outer)
+		for _, arc := range ring.arcs {
+			r.objects = append(r.objects, arc)
+		}
+		r.objects = append(r.objects, ring.inner, ring.label, ring.mask)
+	}
+	r.objects = append(r.objects, r.clock.ZoneLabel)
+}
 
-		// r.DebugPrintContainerCounts()
-		r.DebugPrintArcContainers()
+// Layout fits the rings side by side in size, as large as possible
+func (r *ringRenderer) Layout(size fyne.Size) {
+	c := r.clock
+	n := float32(c.numRings)
+	labelHeight := c.ZoneLabel.MinSize().Height
+	availHeight := size.Height - labelHeight - ringLabelGap
+
+	radius := max(min(size.Width/(2*n+(n-1)*ringSpacingFactor), availHeight/2), 1)
+	spacing := radius * ringSpacingFactor
+	thickness := radius * ringThicknessFactor
+	maskStrokeWidth := radius * ringMaskFactor
+
+	x := (size.Width - (n*2*radius + (n-1)*spacing)) / 2
+	cy := availHeight / 2
+
+	for i, ring := range r.rings {
+		cx := x + radius + float32(i)*(2*radius+spacing)
+
+		ring.outer.Resize(fyne.NewSize(radius*2, radius*2))
+		ring.outer.Move(fyne.NewPos(cx-radius, cy-radius))
+
+		ring.inner.Resize(fyne.NewSize(radius*2-thickness, radius*2-thickness))
+		ring.inner.Move(fyne.NewPos(cx-radius+thickness/2, cy-radius+thickness/2))
+
+		// outer ring mask to cover overshoot from arcs to give perfect circle finish
+		ring.mask.StrokeWidth = maskStrokeWidth
+		ring.mask.Resize(fyne.NewSize(radius*2+maskStrokeWidth, radius*2+maskStrokeWidth))
+		ring.mask.Move(fyne.NewPos(cx-radius-maskStrokeWidth/2, cy-radius-maskStrokeWidth/2))
+
+		ring.label.TextSize = radius * c.labelScaleFactorOfRadius
+		labelSize := ring.label.MinSize()
+		ring.label.Resize(fyne.NewSize(radius*2, labelSize.Height))
+		ring.label.Move(fyne.NewPos(cx-radius, cy-labelSize.Height/2))
+
+		// an arc is a line as wide as the ring segment it covers
+		strokeWidth := radius * float32(c.clockRings[i].arcDegrees*math.Pi/180)
+		for j, arc := range ring.arcs {
+			arc.StrokeWidth = strokeWidth
+			placeHand(arc, cx, cy, radius+float32(c.arcOverShoot), c.arcAngles[i][j])
+		}
 	}
 
+	c.ZoneLabel.Resize(fyne.NewSize(size.Width, labelHeight))
+	c.ZoneLabel.Move(fyne.NewPos(0, size.Height-labelHeight))
 }
 
-func (r *RingClock) DebugPrintContainerCounts() {
-	for i, container := range r.clocksContainer {
-		fmt.Printf("[%s] has %d clock objects\n", r.clockRings[i].Name, len(container.Objects))
-	}
-	fmt.Println("..")
-	for i, container := range r.arcContainers {
-		fmt.Printf("[%s] has %d arc objects\n", r.clockRings[i].Name, len(container.Objects))
+func (r *ringRenderer) MinSize() fyne.Size {
+	c := r.clock
+	n := float32(c.numRings)
+	radius := float32(c.minRadius)
+	return fyne.NewSize(n*2*radius+(n-1)*radius*ringSpacingFactor, radius*2+ringLabelGap+c.ZoneLabel.MinSize().Height)
+}
+
+func (r *ringRenderer) Refresh() {
+	r.syncArcs()
+
+	// the cut out parts follow the theme background
+	background := theme.Color(theme.ColorNameBackground)
+	for _, ring := range r.rings {
+		ring.inner.FillColor = background
+		ring.mask.StrokeColor = background
 	}
 
-	fmt.Println("----")
+	r.Layout(r.clock.Size())
+	for _, obj := range r.objects {
+		obj.Refresh()
+	}
 }
 
-func (r *RingClock) DebugPrintArcContainers() {
-	fmt.Println("Arc Containers Status:")
-	for i, arcContainer := range r.arcContainers {
-		fmt.Printf("  [%s] contains %d objects\n", r.clockRings[i].Name, len(arcContainer.Objects))
-		for j, obj := range arcContainer.Objects {
-			switch o := obj.(type) {
-			case *canvas.Line:
-				fmt.Printf("    Object %d: *canvas.Line StrokeWidth=%.2f Color=%v\n", j, o.StrokeWidth, o.StrokeColor)
-			case *canvas.Circle:
-				fmt.Printf("    Object %d: *canvas.Circle Color=%v\n", j, o.FillColor)
-			case *canvas.Text:
-				fmt.Printf("    Object %d: *canvas.Text Text=%q\n", j, o.Text)
-			default:
-				fmt.Printf("    Object %d: Unknown type %T\n", j, o)
-			}
-		}
-	}
-	fmt.Println("----")
+func (r *ringRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
 }
+
+func (r *ringRenderer) Destroy() {}
@@ -0,0 +1,54 @@
+package clock
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func newTestRingClock(t *testing.T, at time.Time) (*RingClock, *FixedClock) {
+	test.NewTempApp(t)
+
+	source := NewFixedClock(at)
+	c := color.NRGBA{R: 255, A: 255}
+	r := NewRingClock(source, time.UTC, 40, c, c, c, c, c)
+	r.BackFillArcsContainer()
+	return r, source
+}
+
+// the hour ring holds one arc per hour since the last noon or midnight,
+// including the one being shown
+func TestRingClockHourArcs(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         int
+	}{
+		{0, 30, 1},
+		{1, 0, 2},
+		{11, 59, 12},
+		{12, 30, 1},
+		{23, 0, 12},
+	}
+	for _, tt := range tests {
+		r, _ := newTestRingClock(t, time.Date(2024, 10, 18, tt.hour, tt.minute, 0, 0, time.UTC))
+		if got := len(r.arcAngles[2]); got != tt.want {
+			t.Errorf("%02d:%02d: %d hour arcs, want %d", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+}
+
+// after a backfill at 12:30 the next hour adds a single arc
+func TestRingClockHourArcsAfterNoon(t *testing.T) {
+	r, source := newTestRingClock(t, time.Date(2024, 10, 18, 12, 30, 0, 0, time.UTC))
+	tick := NewTickData(source, time.UTC)
+
+	source.Advance(30 * time.Minute)
+	tick.Update()
+	r.Update(tick)
+
+	if got := len(r.arcAngles[2]); got != 2 {
+		t.Errorf("13:00: %d hour arcs, want 2", got)
+	}
+}
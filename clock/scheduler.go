@@ -0,0 +1,183 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Updater is anything the Scheduler can drive with a tick
+type Updater interface {
+	Update(t *TickData)
+}
+
+// UpdaterFunc lets a plain function be registered with a Scheduler
+type UpdaterFunc func(t *TickData)
+
+func (f UpdaterFunc) Update(t *TickData) {
+	f(t)
+}
+
+// Scheduler ticks registered updaters on wall-clock second boundaries.
+// Each tick re-arms a timer for the next boundary instead of using a
+// fixed period, so the display never drifts from the real second.
+// Updaters run on the fyne main goroutine via fyne.Do.
+type Scheduler struct {
+	// JumpThreshold is how far the wall clock may move away from the
+	// monotonic clock between ticks before it is reported as a jump
+	// (suspend/resume or a manual clock change)
+	JumpThreshold time.Duration
+	// FrameRate is used for updaters added with RegisterFrame, set it
+	// before Start
+	FrameRate int
+	// OnMissed is called with the number of skipped seconds when a tick
+	// arrives late, e.g. under heavy load
+	OnMissed func(missed int)
+	// OnJump is called when the clock jumps, from and to are wall times
+	OnJump func(from, to time.Time)
+
+	source TimeSource
+	tick   *TickData
+	frame  *TickData
+
+	mu            sync.Mutex
+	updaters      []Updater
+	frameUpdaters []Updater
+	cancel        context.CancelFunc
+}
+
+func NewScheduler(source TimeSource, loc *time.Location) *Scheduler {
+	source = sourceOrReal(source)
+	return &Scheduler{
+		JumpThreshold: 2 * time.Second,
+		FrameRate:     DefaultSweepFPS,
+		source:        source,
+		tick:          NewTickData(source, loc),
+		frame:         NewTickData(source, loc),
+	}
+}
+
+// Register adds an updater that is ticked once per second
+func (s *Scheduler) Register(u Updater) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updaters = append(s.updaters, u)
+}
+
+// RegisterFrame adds an updater that is ticked at FrameRate, for
+// sweeping hands and animations
+func (s *Scheduler) RegisterFrame(u Updater) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frameUpdaters = append(s.frameUpdaters, u)
+}
+
+// Start launches the tick loops, it is a no-op if already running
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go s.runSeconds(ctx)
+	if len(s.frameUpdaters) > 0 {
+		go s.runFrames(ctx)
+	}
+}
+
+// Stop ends the tick loops started by Start
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *Scheduler) runSeconds(ctx context.Context) {
+	last := s.source.Now()
+	s.dispatch(s.tick, false, last)
+
+	timer := time.NewTimer(untilNextSecond(last))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		now := s.source.Now()
+		s.checkGap(last, now)
+		last = now
+
+		s.dispatch(s.tick, false, now)
+		timer.Reset(untilNextSecond(s.source.Now()))
+	}
+}
+
+func (s *Scheduler) runFrames(ctx context.Context) {
+	ticker := time.NewTicker(FrameInterval(s.FrameRate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.dispatch(s.frame, true, s.source.Now())
+	}
+}
+
+// checkGap compares two consecutive tick times and reports jumps and
+// missed ticks. Jumps are only meaningful for sources running at real
+// speed, a ScaledClock will report one on every tick.
+func (s *Scheduler) checkGap(last, now time.Time) {
+	// Round(0) strips the monotonic reading so Sub compares wall time only
+	wall := now.Round(0).Sub(last.Round(0))
+	elapsed := now.Sub(last)
+
+	if drift := wall - elapsed; wall < 0 || drift > s.JumpThreshold || drift < -s.JumpThreshold {
+		if s.OnJump != nil {
+			s.OnJump(last, now)
+		}
+		return
+	}
+
+	// one second boundary is expected between ticks, every other second
+	// crossed was never shown
+	if missed := int(now.Unix()-last.Unix()) - 1; missed > 0 && s.OnMissed != nil {
+		s.OnMissed(missed)
+	}
+}
+
+func (s *Scheduler) dispatch(t *TickData, frame bool, now time.Time) {
+	s.mu.Lock()
+	targets := s.updaters
+	if frame {
+		targets = s.frameUpdaters
+	}
+	targets = append([]Updater(nil), targets...)
+	s.mu.Unlock()
+
+	fyne.Do(func() {
+		t.set(now)
+		for _, u := range targets {
+			u.Update(t)
+		}
+	})
+}
+
+// @return time left until the next whole second after now
+func untilNextSecond(now time.Time) time.Duration {
+	return now.Truncate(time.Second).Add(time.Second).Sub(now)
+}
@@ -0,0 +1,35 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulerCheckGap(t *testing.T) {
+	base := time.Date(2024, 10, 18, 9, 0, 10, 0, time.UTC)
+	tests := []struct {
+		name       string
+		last, now  time.Duration // after base
+		wantMissed int
+		wantJump   bool
+	}{
+		{"next second", time.Millisecond, time.Second, 0, false},
+		{"late in the same second", 0, 1999 * time.Millisecond, 0, false},
+		{"second 11 skipped", time.Millisecond, 2 * time.Second, 1, false},
+		{"three skipped", 0, 4*time.Second + 500*time.Millisecond, 3, false},
+		{"backwards", 5 * time.Second, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(NewFixedClock(base), time.UTC)
+			missed, jumped := 0, false
+			s.OnMissed = func(n int) { missed = n }
+			s.OnJump = func(time.Time, time.Time) { jumped = true }
+
+			s.checkGap(base.Add(tt.last), base.Add(tt.now))
+			if missed != tt.wantMissed || jumped != tt.wantJump {
+				t.Errorf("missed %d jumped %v, want %d %v", missed, jumped, tt.wantMissed, tt.wantJump)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package clock
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Separator is drawn between the hours, minutes and seconds of a
+// DigitalClock
+type Separator int
+
+const (
+	SeparatorColon Separator = iota
+	SeparatorDot
+	SeparatorDash
+	SeparatorSpace
+)
+
+var separatorNames = [...]string{"colon", "dot", "dash", "space"}
+
+func (s Separator) String() string {
+	if s < 0 || int(s) >= len(separatorNames) {
+		return fmt.Sprintf("Separator(%d)", int(s))
+	}
+	return separatorNames[s]
+}
+
+// ParseSeparator reads a separator name, empty is a colon
+func ParseSeparator(name string) (Separator, error) {
+	if name == "" {
+		return SeparatorColon, nil
+	}
+	for i, n := range separatorNames {
+		if strings.EqualFold(name, n) {
+			return Separator(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown separator %q, want one of %s", name, strings.Join(separatorNames[:], ", "))
+}
+
+// shapes of the separator for digits of geometry g, segment thick and
+// leaning with the digits
+func (s Separator) shapes(g DigitGeometry) []SegmentRect {
+	t, h := g.thickness(), g.Height
+	dot := func(y, width float32) SegmentRect {
+		return SegmentRect{Position: g.skew(fyne.NewPos(0, y+t/2)).SubtractXY(0, t/2), Size: fyne.NewSize(width, t)}
+	}
+
+	switch s {
+	case SeparatorDot:
+		// sits on the baseline like a decimal point
+		return []SegmentRect{dot(h-t, t)}
+	case SeparatorDash:
+		return []SegmentRect{dot((h-t)/2, 2*t)}
+	case SeparatorSpace:
+		return nil
+	}
+	// the dots of the 110 high default digit are at 20 and 60
+	return []SegmentRect{dot(h*2/11, t), dot(h*6/11, t)}
+}
+
+// rune is the character drawn for the separator on a dot matrix
+func (s Separator) rune() rune {
+	switch s {
+	case SeparatorDot:
+		return '.'
+	case SeparatorDash:
+		return '-'
+	case SeparatorSpace:
+		return ' '
+	}
+	return ':'
+}
+
+// ColonBlink is how often the separators of a DigitalClock blink. The
+// blink happens within a second, so it only animates when the clock gets
+// frame updates: a clock that blinks reports Sweep and the board moves it
+// from UpdateFrame, which main registers with Scheduler.RegisterFrame.
+// Ticked once a second the separators stay in the lit half.
+type ColonBlink int
+
+const (
+	ColonSteady   ColonBlink = iota
+	ColonBlink1Hz            // lit for the first half of every second
+	ColonBlink2Hz            // lit for the first half of every half second
+)
+
+var colonBlinkNames = [...]string{"steady", "1hz", "2hz"}
+
+func (b ColonBlink) String() string {
+	if b < 0 || int(b) >= len(colonBlinkNames) {
+		return fmt.Sprintf("ColonBlink(%d)", int(b))
+	}
+	return colonBlinkNames[b]
+}
+
+// ParseColonBlink reads steady, 1hz or 2hz, empty is steady
+func ParseColonBlink(name string) (ColonBlink, error) {
+	if name == "" {
+		return ColonSteady, nil
+	}
+	for i, n := range colonBlinkNames {
+		if strings.EqualFold(name, n) {
+			return ColonBlink(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown colon blink %q, want one of %s", name, strings.Join(colonBlinkNames[:], ", "))
+}
+
+// lit reports whether the separators are on at t
+func (b ColonBlink) lit(t *TickData) bool {
+	const half = 500_000_000 // nanoseconds
+	switch b {
+	case ColonBlink1Hz:
+		return t.Nanosecond < half
+	case ColonBlink2Hz:
+		return t.Nanosecond%half < half/2
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package clock
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestColonBlinkLit(t *testing.T) {
+	tests := []struct {
+		blink ColonBlink
+		nanos int
+		want  bool
+	}{
+		{ColonSteady, 0, true},
+		{ColonSteady, 750_000_000, true},
+		{ColonBlink1Hz, 0, true},
+		{ColonBlink1Hz, 499_999_999, true},
+		{ColonBlink1Hz, 500_000_000, false},
+		{ColonBlink1Hz, 999_999_999, false},
+		{ColonBlink2Hz, 0, true},
+		{ColonBlink2Hz, 249_999_999, true},
+		{ColonBlink2Hz, 250_000_000, false},
+		{ColonBlink2Hz, 499_999_999, false},
+		{ColonBlink2Hz, 500_000_000, true},
+		{ColonBlink2Hz, 750_000_000, false},
+	}
+	for _, tt := range tests {
+		tick := NewTickData(NewFixedClock(time.Date(2026, 10, 18, 9, 30, 15, tt.nanos, time.UTC)), time.UTC)
+		if got := tt.blink.lit(tick); got != tt.want {
+			t.Errorf("%v at %dns: got lit %v, want %v", tt.blink, tt.nanos, got, tt.want)
+		}
+	}
+}
+
+func TestSeparatorShapes(t *testing.T) {
+	rect := func(x, y, w, h float32) SegmentRect {
+		return SegmentRect{Position: fyne.NewPos(x, y), Size: fyne.NewSize(w, h)}
+	}
+	upright := NewDigitGeometry(70) // 70x110 with 10 thick segments
+	slanted := upright
+	slanted.Slant = 0.1
+
+	tests := []struct {
+		separator Separator
+		geometry  DigitGeometry
+		want      []SegmentRect
+	}{
+		{SeparatorColon, upright, []SegmentRect{rect(0, 20, 10, 10), rect(0, 60, 10, 10)}},
+		{SeparatorDot, upright, []SegmentRect{rect(0, 100, 10, 10)}},
+		{SeparatorDash, upright, []SegmentRect{rect(0, 50, 20, 10)}},
+		{SeparatorSpace, upright, nil},
+		// the upper dot leans further right, by a tenth of its height above the baseline
+		{SeparatorColon, slanted, []SegmentRect{rect(8.5, 20, 10, 10), rect(4.5, 60, 10, 10)}},
+		{SeparatorDot, slanted, []SegmentRect{rect(0.5, 100, 10, 10)}},
+	}
+	for _, tt := range tests {
+		got := tt.separator.shapes(tt.geometry)
+		if len(got) != len(tt.want) {
+			t.Errorf("%v slant %g: got %v, want %v", tt.separator, tt.geometry.Slant, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !closePos(got[i].Position, tt.want[i].Position) || got[i].Size != tt.want[i].Size {
+				t.Errorf("%v slant %g: shape %d got %v, want %v", tt.separator, tt.geometry.Slant, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	runes := []rune{SeparatorColon.rune(), SeparatorDot.rune(), SeparatorDash.rune(), SeparatorSpace.rune()}
+	if want := []rune(":.- "); !slices.Equal(runes, want) {
+		t.Errorf("matrix runes: got %q, want %q", runes, want)
+	}
+}
+
+func closePos(a, b fyne.Position) bool {
+	const eps = 1e-4
+	d := a.Subtract(b)
+	return d.X > -eps && d.X < eps && d.Y > -eps && d.Y < eps
+}
+
+// separatorFill is the colour of the first separator on d
+func separatorFill(d *DigitalClock) color.Color {
+	return d.digits[2].Objects[0].(*canvas.Rectangle).FillColor
+}
+
+// the blink only moves on frame updates, a blinking clock asks for them
+// with Sweep and the board leaves it out of the once a second Update
+func TestColonBlinkNeedsFrames(t *testing.T) {
+	test.NewTempApp(t)
+
+	source := NewFixedClock(time.Date(2026, 10, 18, 9, 30, 15, 0, time.UTC))
+	board, err := NewWorldClockBoard(source, []BoardEntry{
+		{Face: FaceDigital, Zone: "UTC", Digital: DigitalOptions{ColonBlink: ColonBlink1Hz}},
+		{Face: FaceDigital, Zone: "UTC"},
+	}, DefaultPalette(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blinking := board.cells[0].face.(*DigitalClock)
+	steady := board.cells[1].face.(*DigitalClock)
+	if !blinking.Sweep() || steady.Sweep() || !board.Sweeping() {
+		t.Fatalf("Sweep: blinking %v, steady %v, board %v", blinking.Sweep(), steady.Sweep(), board.Sweeping())
+	}
+
+	tick := NewTickData(source, time.UTC)
+	source.Advance(600 * time.Millisecond)
+	tick.Update()
+
+	board.Update(tick)
+	if !blinking.separatorLit || separatorFill(blinking) != blinking.SevenSegmentDisplay.onColor {
+		t.Error("the once a second Update blinked the separators")
+	}
+
+	board.UpdateFrame(tick)
+	if blinking.separatorLit || separatorFill(blinking) != color.Transparent {
+		t.Error("UpdateFrame did not blink the separators off in the second half of the second")
+	}
+	if !steady.separatorLit {
+		t.Error("a steady separator went off")
+	}
+
+	source.Advance(500 * time.Millisecond)
+	tick.Update()
+	board.UpdateFrame(tick)
+	if !blinking.separatorLit || separatorFill(blinking) != blinking.SevenSegmentDisplay.onColor {
+		t.Error("UpdateFrame did not relight the separators")
+	}
+}
@@ -2,16 +2,17 @@
 package clock
 
 import (
-	"fmt"
-	"strconv"
 	"time"
 )
 
 type TickData struct {
-	Hour12        int
+	Hour12        int // 1-12, noon and midnight are 12
 	Hour24        int
+	PM            bool // from noon until midnight
 	Minute        int
 	Second        int
+	Millisecond   int // 0-999 within Second
+	Nanosecond    int // 0-999999999 within Second
 	Hr12OnesDigit int
 	Hr12TensDigit int
 	Hr24OnesDigit int
@@ -20,62 +21,48 @@ type TickData struct {
 	MinTensDigit  int
 	SecOnesDigit  int
 	SecTensDigit  int
+	Year          int
+	Month         time.Month
+	Day           int // 1-31
+	Weekday       time.Weekday
+	YearDay       int            // 1-366
+	ISOYear       int            // year the ISO week belongs to, differs from Year around new year
+	ISOWeek       int            // 1-53
+	Time          time.Time      // instant of the last update, in Location
+	Location      *time.Location // zone the fields above are expressed in
 	prevSec       int
 	prevMin       int
 	prevHr        int
+	source        TimeSource
+	subscribers   tickSubscribers
 }
 
-func NewTickData() *TickData {
-	now := time.Now()
-
-	Hour12 := (now.Hour()) % 12
-	Hour24 := now.Hour()
-	Minute := now.Minute()
-	Second := now.Second()
-
-	hour12Str := fmt.Sprintf("%02d", Hour12)
-	hour24Str := fmt.Sprintf("%02d", Hour24)
-	minuteStr := fmt.Sprintf("%02d", Minute)
-	secondStr := fmt.Sprintf("%02d", Second)
-
-	Hr12TensDigit, _ := strconv.Atoi(string(hour12Str[0]))
-	Hr12OnesDigit, _ := strconv.Atoi(string(hour12Str[1]))
-
-	Hr24TensDigit, _ := strconv.Atoi(string(hour24Str[0]))
-	Hr24OnesDigit, _ := strconv.Atoi(string(hour24Str[1]))
-
-	MinTensDigit, _ := strconv.Atoi(string(minuteStr[0]))
-	MinOnesDigit, _ := strconv.Atoi(string(minuteStr[1]))
-
-	SecTensDigit, _ := strconv.Atoi(string(secondStr[0]))
-	SecOnesDigit, _ := strconv.Atoi(string(secondStr[1]))
-
-	return &TickData{
-		Hour12:        Hour12,
-		Hour24:        Hour24,
-		Minute:        Minute,
-		Second:        Second,
-		Hr12OnesDigit: Hr12OnesDigit,
-		Hr12TensDigit: Hr12TensDigit,
-		Hr24OnesDigit: Hr24OnesDigit,
-		Hr24TensDigit: Hr24TensDigit,
-
-		MinOnesDigit: MinOnesDigit,
-		MinTensDigit: MinTensDigit,
-		SecOnesDigit: SecOnesDigit,
-		SecTensDigit: SecTensDigit,
-
-		prevSec: -1,
-		prevMin: -1,
-		prevHr:  -1,
+// NewTickData fills the tick from source in zone loc.
+// A nil source reads the wall clock and a nil loc uses time.Local.
+func NewTickData(source TimeSource, loc *time.Location) *TickData {
+	source = sourceOrReal(source)
+	if loc == nil {
+		loc = time.Local
 	}
+
+	t := &TickData{
+		Location: loc,
+		source:   source,
+	}
+	t.fill(source.Now())
+
+	t.prevSec = -1
+	t.prevMin = -1
+	t.prevHr = -1
+
+	return t
 }
 
 func (time *TickData) GetClockAngles() []float64 {
 
 	var anglesArr []float64
 
-	hourAngle := (float64(time.Hour12) + float64(time.Minute)/60.0) * 30.0
+	hourAngle := (float64(time.dialHour()) + float64(time.Minute)/60.0) * 30.0
 	minuteAngle := float64(time.Minute) * 6
 	secondAngle := float64(time.Second) * 6
 
@@ -84,37 +71,74 @@ func (time *TickData) GetClockAngles() []float64 {
 	return anglesArr
 }
 
+// dialHour is the hour on a 12 hour dial, 0-11 so noon and midnight sit
+// at the top rather than a full turn round
+func (t *TickData) dialHour() int {
+	return t.Hour24 % 12
+}
+
+// FractionalSecond is Second plus the elapsed fraction of it, e.g. 12.25
+func (t *TickData) FractionalSecond() float64 {
+	return float64(t.Second) + float64(t.Nanosecond)/float64(time.Second)
+}
+
+// Update reads the time source and refreshes all fields
 func (t *TickData) Update() {
-	now := time.Now()
+	t.set(t.source.Now())
+}
+
+// Sync moves t to the instant held by other, keeping t's own location.
+// Faces use it to show a shared tick in their own time zone.
+func (t *TickData) Sync(other *TickData) {
+	t.set(other.Time)
+}
 
+func (t *TickData) set(now time.Time) {
 	// Save previous values
 	t.prevHr = t.Hour12
 	t.prevMin = t.Minute
 	t.prevSec = t.Second
+	prevTime := t.Time
+
+	t.fill(now)
+	t.emit(prevTime)
+}
 
-	// Update current values
+func (t *TickData) fill(now time.Time) {
+	now = now.In(t.Location)
+
+	t.Time = now
 	t.Hour12 = now.Hour() % 12
+	if t.Hour12 == 0 {
+		t.Hour12 = 12
+	}
 	t.Hour24 = now.Hour()
+	t.PM = t.Hour24 >= 12
 	t.Minute = now.Minute()
 	t.Second = now.Second()
+	t.Nanosecond = now.Nanosecond()
+	t.Millisecond = t.Nanosecond / int(time.Millisecond)
+
+	t.Hr12TensDigit, t.Hr12OnesDigit = splitDigits(t.Hour12)
+	t.Hr24TensDigit, t.Hr24OnesDigit = splitDigits(t.Hour24)
+	t.MinTensDigit, t.MinOnesDigit = splitDigits(t.Minute)
+	t.SecTensDigit, t.SecOnesDigit = splitDigits(t.Second)
+
+	t.Year, t.Month, t.Day = now.Date()
+	t.Weekday = now.Weekday()
+	t.YearDay = now.YearDay()
+	t.ISOYear, t.ISOWeek = now.ISOWeek()
+}
 
-	// Update digit fields (optional, keep if needed)
-	hour12Str := fmt.Sprintf("%02d", t.Hour12)
-	hour24Str := fmt.Sprintf("%02d", t.Hour24)
-	minuteStr := fmt.Sprintf("%02d", t.Minute)
-	secondStr := fmt.Sprintf("%02d", t.Second)
-
-	t.Hr12TensDigit, _ = strconv.Atoi(string(hour12Str[0]))
-	t.Hr12OnesDigit, _ = strconv.Atoi(string(hour12Str[1]))
-
-	t.Hr24TensDigit, _ = strconv.Atoi(string(hour24Str[0]))
-	t.Hr24OnesDigit, _ = strconv.Atoi(string(hour24Str[1]))
-
-	t.MinTensDigit, _ = strconv.Atoi(string(minuteStr[0]))
-	t.MinOnesDigit, _ = strconv.Atoi(string(minuteStr[1]))
+// @return tens, ones digits of a two digit value
+func splitDigits(n int) (int, int) {
+	return n / 10, n % 10
+}
 
-	t.SecTensDigit, _ = strconv.Atoi(string(secondStr[0]))
-	t.SecOnesDigit, _ = strconv.Atoi(string(secondStr[1]))
+// ZoneName returns the IANA name of the tick's zone, or its abbreviation
+// when the zone has no useful name (e.g. "Local")
+func (t *TickData) ZoneName() string {
+	return ZoneLabel(t.Location, t.Time)
 }
 
 func (t *TickData) SecondChanged() bool {
@@ -0,0 +1,121 @@
+package clock
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewTickData(t *testing.T) {
+	tests := []struct {
+		at         string
+		hour12     int
+		pm         bool
+		hr12Digits [2]int
+		hr24Digits [2]int
+		angles     []float64 // second, minute, hour
+	}{
+		{"00:30:15", 12, false, [2]int{1, 2}, [2]int{0, 0}, []float64{90, 180, 15}},
+		{"09:05:00", 9, false, [2]int{0, 9}, [2]int{0, 9}, []float64{0, 30, 272.5}},
+		{"11:59:59", 11, false, [2]int{1, 1}, [2]int{1, 1}, []float64{354, 354, 359.5}},
+		{"12:30:15", 12, true, [2]int{1, 2}, [2]int{1, 2}, []float64{90, 180, 15}},
+		{"13:00:00", 1, true, [2]int{0, 1}, [2]int{1, 3}, []float64{0, 0, 30}},
+		{"23:45:30", 11, true, [2]int{1, 1}, [2]int{2, 3}, []float64{180, 270, 352.5}},
+	}
+	for _, tt := range tests {
+		at, err := time.ParseInLocation("2006-01-02 15:04:05", "2024-10-18 "+tt.at, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tick := NewTickData(NewFixedClock(at), time.UTC)
+
+		if tick.Hour12 != tt.hour12 || tick.PM != tt.pm {
+			t.Errorf("%s: got hour12 %d pm %v, want %d %v", tt.at, tick.Hour12, tick.PM, tt.hour12, tt.pm)
+		}
+		if got := [2]int{tick.Hr12TensDigit, tick.Hr12OnesDigit}; got != tt.hr12Digits {
+			t.Errorf("%s: got 12 hour digits %v, want %v", tt.at, got, tt.hr12Digits)
+		}
+		if got := [2]int{tick.Hr24TensDigit, tick.Hr24OnesDigit}; got != tt.hr24Digits {
+			t.Errorf("%s: got 24 hour digits %v, want %v", tt.at, got, tt.hr24Digits)
+		}
+		if got := tick.GetClockAngles(); !slices.Equal(got, tt.angles) {
+			t.Errorf("%s: got angles %v, want %v", tt.at, got, tt.angles)
+		}
+		if !tick.SecondChanged() || !tick.MinuteChanged() || !tick.HourChanged() {
+			t.Errorf("%s: a new tick should report every unit changed", tt.at)
+		}
+	}
+}
+
+func TestTickDataUpdate(t *testing.T) {
+	source := NewFixedClock(time.Date(2024, 10, 18, 9, 59, 58, 0, time.UTC))
+	tick := NewTickData(source, time.UTC)
+
+	tests := []struct {
+		advance                time.Duration
+		second, minute, hour24 int
+		secChanged, minChanged bool
+		hourChanged            bool
+	}{
+		{500 * time.Millisecond, 58, 59, 9, false, false, false},
+		{500 * time.Millisecond, 59, 59, 9, true, false, false},
+		{time.Second, 0, 0, 10, true, true, true},
+		{time.Minute, 0, 1, 10, false, true, false},
+	}
+	for i, tt := range tests {
+		source.Advance(tt.advance)
+		tick.Update()
+
+		if tick.Second != tt.second || tick.Minute != tt.minute || tick.Hour24 != tt.hour24 {
+			t.Errorf("step %d: got %02d:%02d:%02d, want %02d:%02d:%02d", i,
+				tick.Hour24, tick.Minute, tick.Second, tt.hour24, tt.minute, tt.second)
+		}
+		changed := [3]bool{tick.SecondChanged(), tick.MinuteChanged(), tick.HourChanged()}
+		if want := [3]bool{tt.secChanged, tt.minChanged, tt.hourChanged}; changed != want {
+			t.Errorf("step %d: got second, minute, hour changed %v, want %v", i, changed, want)
+		}
+	}
+}
+
+func TestTimeSources(t *testing.T) {
+	start := time.Date(2024, 10, 18, 12, 0, 0, 0, time.UTC)
+	fixed := NewFixedClock(start)
+	offset := NewOffsetClock(fixed, -90*time.Minute)
+	scaled := NewScaledClock(fixed, start.Add(time.Hour), 60)
+
+	tests := []struct {
+		name    string
+		source  TimeSource
+		advance time.Duration // of the fixed clock underneath, the rows run in order
+		want    time.Time
+	}{
+		{"fixed", fixed, 0, start},
+		{"fixed advanced", fixed, 2 * time.Second, start.Add(2 * time.Second)},
+		{"offset", offset, 0, start.Add(2*time.Second - 90*time.Minute)},
+		{"offset follows its source", offset, time.Second, start.Add(3*time.Second - 90*time.Minute)},
+		{"scaled", scaled, 0, start.Add(time.Hour + 3*time.Minute)},
+		{"scaled runs 60 times faster", scaled, 2 * time.Second, start.Add(time.Hour + 5*time.Minute)},
+	}
+	for _, tt := range tests {
+		fixed.Advance(tt.advance)
+		if got := tt.source.Now(); !got.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTickDataSyncKeepsLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	source := NewFixedClock(time.Date(2024, 10, 18, 20, 30, 0, 0, time.UTC))
+	shared := NewTickData(source, time.UTC)
+	face := NewTickData(source, tokyo)
+
+	source.Advance(time.Hour)
+	shared.Update()
+	face.Sync(shared)
+
+	if face.Hour24 != 6 || face.Day != 19 || face.Location != tokyo {
+		t.Errorf("got %02d:%02d on the %d in %v, want 06:30 on the 19 in JST",
+			face.Hour24, face.Minute, face.Day, face.Location)
+	}
+}
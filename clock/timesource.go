@@ -0,0 +1,90 @@
+package clock
+
+import (
+	"time"
+)
+
+// TimeSource supplies the current time to TickData and the clock faces
+type TimeSource interface {
+	Now() time.Time
+}
+
+// RealClock reads the system wall clock
+type RealClock struct{}
+
+func NewRealClock() RealClock {
+	return RealClock{}
+}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always reports the same instant, useful for tests and screenshots
+type FixedClock struct {
+	Time time.Time
+}
+
+func NewFixedClock(t time.Time) *FixedClock {
+	return &FixedClock{Time: t}
+}
+
+func (f *FixedClock) Now() time.Time {
+	return f.Time
+}
+
+// Set moves the fixed clock to a new instant
+func (f *FixedClock) Set(t time.Time) {
+	f.Time = t
+}
+
+// Advance moves the fixed clock forward by d
+func (f *FixedClock) Advance(d time.Duration) {
+	f.Time = f.Time.Add(d)
+}
+
+// OffsetClock reports the time of another source shifted by a constant offset
+type OffsetClock struct {
+	Source TimeSource
+	Offset time.Duration
+}
+
+func NewOffsetClock(source TimeSource, offset time.Duration) *OffsetClock {
+	return &OffsetClock{Source: source, Offset: offset}
+}
+
+func (o *OffsetClock) Now() time.Time {
+	return o.Source.Now().Add(o.Offset)
+}
+
+// ScaledClock runs another source faster or slower than real time.
+// Time starts at Start and advances Scale seconds for every second
+// elapsed on Source since the clock was created.
+type ScaledClock struct {
+	Source TimeSource
+	Start  time.Time
+	Scale  float64
+	origin time.Time
+}
+
+func NewScaledClock(source TimeSource, start time.Time, scale float64) *ScaledClock {
+	return &ScaledClock{
+		Source: source,
+		Start:  start,
+		Scale:  scale,
+		origin: source.Now(),
+	}
+}
+
+func (s *ScaledClock) Now() time.Time {
+	elapsed := s.Source.Now().Sub(s.origin)
+	return s.Start.Add(time.Duration(float64(elapsed) * s.Scale))
+}
+
+// fall back to the wall clock when no source is given
+func sourceOrReal(source TimeSource) TimeSource {
+	if source == nil {
+		return RealClock{}
+	}
+	return source
+}
@@ -0,0 +1,36 @@
+package clock
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// LoadZone resolves an IANA zone name such as "Europe/London".
+// An empty name or "Local" gives the process zone.
+func LoadZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// ZoneLabel gives the text shown under a face for loc at instant t.
+// Named zones show their IANA name, the process zone shows its abbreviation.
+func ZoneLabel(loc *time.Location, t time.Time) string {
+	if loc == nil || loc == time.Local || loc.String() == "Local" {
+		abbr, _ := t.In(time.Local).Zone()
+		return abbr
+	}
+	return loc.String()
+}
+
+// zone labels are centred in the width given to them by the face layout
+func drawZoneLabel(text string) *canvas.Text {
+	label := canvas.NewText(text, color.Gray{Y: 0xaa})
+	label.TextSize = 14
+	label.Alignment = fyne.TextAlignCenter
+	return label
+}
@@ -0,0 +1,215 @@
+// Package config loads the clock layout, colours and faces from a TOML or
+// YAML file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"temp.com/go-clock/clock"
+)
+
+// DefaultPaths are tried in order by LoadDefault
+var DefaultPaths = []string{"go-clock.toml", "go-clock.yaml", "go-clock.yml"}
+
+type Config struct {
+	Mode24h   bool            `toml:"mode24h" yaml:"mode24h"`
+	LowPower  bool            `toml:"low_power" yaml:"low_power"` // turns off segment afterglow
+	Window    WindowConfig    `toml:"window" yaml:"window"`
+	Colors    ColorConfig     `toml:"colors" yaml:"colors"`
+	Faces     []FaceConfig    `toml:"faces" yaml:"faces"`
+	Animation AnimationConfig `toml:"animation" yaml:"animation"`
+
+	path  string         // file the config was read from, empty for defaults
+	lines map[string]int // key path such as "faces.1.zone" to line number
+}
+
+type WindowConfig struct {
+	Width  int `toml:"width" yaml:"width"`
+	Height int `toml:"height" yaml:"height"`
+}
+
+// AnimationConfig is the mascot panel next to the clocks
+type AnimationConfig struct {
+	Path  string `toml:"path" yaml:"path"`   // empty shows the built in Dat Boi
+	Tempo string `toml:"tempo" yaml:"tempo"` // see animation.ParseTempo, empty runs free
+
+	// Playlist is a directory to rotate through instead of Path, switching
+	// Every interval such as "15m" or At times of day such as "09:00"
+	Playlist  string   `toml:"playlist" yaml:"playlist"`
+	Every     string   `toml:"every" yaml:"every"`
+	At        []string `toml:"at" yaml:"at"`
+	Crossfade string   `toml:"crossfade" yaml:"crossfade"` // e.g. "1s", empty cuts straight over
+}
+
+// ColorConfig holds hex colours such as "#ff9632", empty entries inherit
+type ColorConfig struct {
+	On     string `toml:"on" yaml:"on"`
+	Off    string `toml:"off" yaml:"off"`
+	Stroke string `toml:"stroke" yaml:"stroke"`
+	Second string `toml:"second" yaml:"second"`
+	Minute string `toml:"minute" yaml:"minute"`
+	Hour   string `toml:"hour" yaml:"hour"`
+}
+
+// FaceConfig is one face on the board
+type FaceConfig struct {
+	Type    string      `toml:"type" yaml:"type"`
+	Zone    string      `toml:"zone" yaml:"zone"`
+	Label   string      `toml:"label" yaml:"label"`
+	Sweep   bool        `toml:"sweep" yaml:"sweep"`
+	Size    int         `toml:"size" yaml:"size"`
+	Mode24h *bool       `toml:"mode24h" yaml:"mode24h"`
+	Colors  ColorConfig `toml:"colors" yaml:"colors"`
+
+	// digital faces only
+	HideLeadingZero bool    `toml:"hide_leading_zero" yaml:"hide_leading_zero"`
+	HideSeconds     bool    `toml:"hide_seconds" yaml:"hide_seconds"`
+	Separator       string  `toml:"separator" yaml:"separator"`         // colon, dot, dash or space
+	ColonBlink      string  `toml:"colon_blink" yaml:"colon_blink"`     // steady, 1hz or 2hz
+	DimColon        bool    `toml:"dim_colon" yaml:"dim_colon"`         // blinked off separators show the off colour
+	DotMatrix       string  `toml:"dot_matrix" yaml:"dot_matrix"`       // 5x7 or 8x8 LEDs, empty for seven segments
+	Aspect          float32 `toml:"aspect" yaml:"aspect"`               // digit height as a multiple of its width, default 110/70
+	Thickness       float32 `toml:"thickness" yaml:"thickness"`         // segment thickness as a fraction of the digit width
+	Slant           float32 `toml:"slant" yaml:"slant"`                 // lean of the digits as a fraction of their height
+	SegmentStyle    string  `toml:"segment_style" yaml:"segment_style"` // rectangle, hexagon or bevel
+	Afterglow       string  `toml:"afterglow" yaml:"afterglow"`         // fade time of segments switching off, e.g. "150ms"
+	Date            string  `toml:"date" yaml:"date"`                   // off, alternate or row
+	DateEvery       string  `toml:"date_every" yaml:"date_every"`       // alternate: how often the date shows, default "30s"
+	DateFor         string  `toml:"date_for" yaml:"date_for"`           // alternate: how long it shows, default "3s"
+	MonthFirst      bool    `toml:"month_first" yaml:"month_first"`     // MM.DD instead of DD.MM
+}
+
+// Default is the layout used when there is no config file
+func Default() *Config {
+	return &Config{
+		Mode24h: true,
+		Window:  WindowConfig{Width: 800, Height: 600},
+		Colors: ColorConfig{
+			On:     "#ff9632",
+			Off:    "#323232",
+			Stroke: "#c8641e",
+			Second: "#00ff64",
+			Minute: "#3296ff",
+			Hour:   "#ff5050",
+		},
+		Faces: defaultFaces(),
+	}
+}
+
+func defaultFaces() []FaceConfig {
+	return []FaceConfig{
+		{Type: clock.FaceAnalog, Sweep: true},
+		{Type: clock.FaceDigital},
+		{Type: clock.FaceRing},
+	}
+}
+
+// LoadDefault reads the first of DefaultPaths that exists, or returns
+// Default when none do
+func LoadDefault() (*Config, error) {
+	for _, path := range DefaultPaths {
+		cfg, err := Load(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return cfg, err
+	}
+	return Default(), nil
+}
+
+// Load reads and validates a config file, the format is picked from the
+// file extension
+func Load(path string) (*Config, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, src)
+}
+
+// Parse decodes src as TOML or YAML depending on the extension of name.
+// Settings missing from src keep their default value.
+func Parse(name string, src []byte) (*Config, error) {
+	cfg := Default()
+	cfg.Faces = nil
+	cfg.path = name
+
+	var unknown []error
+	var err error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".toml":
+		unknown, err = cfg.decodeTOML(src)
+	case ".yaml", ".yml":
+		err = cfg.decodeYAML(src)
+	default:
+		err = fmt.Errorf("%s: unsupported config format, use .toml or .yaml", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Faces) == 0 {
+		cfg.Faces = defaultFaces()
+	}
+
+	if err := errors.Join(append(unknown, cfg.Validate())...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// decodeTOML returns keys that do not match a setting separately so they
+// can be reported together with validation errors
+func (c *Config) decodeTOML(src []byte) ([]error, error) {
+	md, err := toml.Decode(string(src), c)
+	if err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			return nil, fmt.Errorf("%s:%d: %s", c.path, perr.Position.Line, perr.Message)
+		}
+		return nil, fmt.Errorf("%s: %w", c.path, err)
+	}
+
+	c.lines = tomlLines(src)
+
+	var unknown []error
+	for _, key := range md.Undecoded() {
+		unknown = append(unknown, c.fieldError(key.String(), errors.New("unknown setting")))
+	}
+	return unknown, nil
+}
+
+func (c *Config) decodeYAML(src []byte) error {
+	if len(bytes.TrimSpace(src)) == 0 {
+		c.lines = map[string]int{}
+		return nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(src, &root); err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+	c.lines = map[string]int{}
+	yamlLines(&root, "", c.lines)
+
+	return nil
+}
+
+// Path is the file the config was loaded from, empty for Default
+func (c *Config) Path() string {
+	return c.path
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tomlLines maps each key path to the line it is set on. Arrays of tables
+// get their index in the path, "faces.1.zone" is the zone of the second
+// [[faces]]. The same path without indexes is also recorded for the first
+// occurrence so keys reported by the decoder can be found.
+func tomlLines(src []byte) map[string]int {
+	lines := map[string]int{}
+	arrays := map[string]int{} // resolved array table path to its last index
+	prefix := ""
+
+	record := func(path string, line int) {
+		lines[path] = line
+		plain := stripIndexes(path)
+		if _, ok := lines[plain]; !ok {
+			lines[plain] = line
+		}
+	}
+
+	// resolve puts the current index after every array table in name
+	resolve := func(name string) string {
+		path := ""
+		for _, seg := range strings.Split(name, ".") {
+			path = joinKey(path, unquote(seg))
+			if i, ok := arrays[path]; ok {
+				path = joinKey(path, strconv.Itoa(i))
+			}
+		}
+		return path
+	}
+
+	for n, raw := range strings.Split(string(src), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "[["):
+			name := strings.TrimSpace(strings.Trim(line[:strings.Index(line+"]]", "]]")], "[ "))
+			parent, last := "", name
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				parent, last = resolve(name[:i]), name[i+1:]
+			}
+			array := joinKey(parent, unquote(last))
+			index := 0
+			if i, ok := arrays[array]; ok {
+				index = i + 1
+			}
+			arrays[array] = index
+			prefix = joinKey(array, strconv.Itoa(index))
+			record(prefix, n+1)
+		case strings.HasPrefix(line, "["):
+			prefix = resolve(strings.TrimSpace(strings.Trim(line[:strings.Index(line+"]", "]")], "[ ")))
+			record(prefix, n+1)
+		default:
+			eq := strings.Index(line, "=")
+			if eq < 0 {
+				continue
+			}
+			key := prefix
+			for _, seg := range strings.Split(strings.TrimSpace(line[:eq]), ".") {
+				key = joinKey(key, unquote(seg))
+			}
+			record(key, n+1)
+		}
+	}
+	return lines
+}
+
+// yamlLines walks a parsed document recording the line of every key and
+// sequence item under path
+func yamlLines(node *yaml.Node, path string, lines map[string]int) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			yamlLines(child, path, lines)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := joinKey(path, node.Content[i].Value)
+			lines[key] = node.Content[i].Line
+			yamlLines(node.Content[i+1], key, lines)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			key := joinKey(path, strconv.Itoa(i))
+			lines[key] = item.Line
+			yamlLines(item, key, lines)
+		}
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func unquote(key string) string {
+	return strings.Trim(strings.TrimSpace(key), `"'`)
+}
+
+// stripIndexes drops the numeric segments of a key path
+func stripIndexes(path string) string {
+	segs := strings.Split(path, ".")
+	kept := segs[:0]
+	for _, seg := range segs {
+		if _, err := strconv.Atoi(seg); err != nil {
+			kept = append(kept, seg)
+		}
+	}
+	return strings.Join(kept, ".")
+}
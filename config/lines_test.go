@@ -0,0 +1,205 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testTOML = `mode24h = true
+
+[window]
+width = 640
+
+[[faces]]
+type = "analog"
+zone = "Europe/London"
+[faces.colors]
+on = "#ff0000"
+
+# a comment between faces
+[[faces]]
+type = "digital"
+"zone" = "Bad/Zone"
+colors.hour = "#00ff00"
+
+[faces.colors]
+on = "not a colour"
+
+[[faces.marks]]
+at = 1
+
+[[faces.marks]]
+at = 2
+`
+
+func TestTOMLLines(t *testing.T) {
+	lines := tomlLines([]byte(testTOML))
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"mode24h", 1},
+		{"window", 3},
+		{"window.width", 4},
+		{"faces.0", 6},
+		{"faces.0.zone", 8},
+		{"faces.0.colors", 9},
+		{"faces.0.colors.on", 10},
+		{"faces.1", 13},
+		{"faces.1.zone", 15},
+		{"faces.1.colors.hour", 16},
+		{"faces.1.colors.on", 19},
+		{"faces.1.marks.0.at", 22},
+		{"faces.1.marks.1.at", 25},
+		// without indexes the first occurrence wins
+		{"faces.zone", 8},
+		{"faces.colors.on", 10},
+		{"faces.marks.at", 22},
+	}
+	for _, tt := range tests {
+		if got := lines[tt.key]; got != tt.want {
+			t.Errorf("%s: got line %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
+
+const testYAML = `mode24h: true
+window:
+  width: 640
+faces:
+  - type: analog
+    zone: Europe/London
+    colors:
+      on: "#ff0000"
+  - type: digital
+    zone: Bad/Zone
+    colors:
+      hour: "#00ff00"
+      on: not a colour
+`
+
+func TestYAMLLines(t *testing.T) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(testYAML), &root); err != nil {
+		t.Fatal(err)
+	}
+	lines := map[string]int{}
+	yamlLines(&root, "", lines)
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"mode24h", 1},
+		{"window.width", 3},
+		{"faces.0", 5},
+		{"faces.0.zone", 6},
+		{"faces.0.colors.on", 8},
+		{"faces.1", 9},
+		{"faces.1.zone", 10},
+		{"faces.1.colors.hour", 12},
+		{"faces.1.colors.on", 13},
+	}
+	for _, tt := range tests {
+		if got := lines[tt.key]; got != tt.want {
+			t.Errorf("%s: got line %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
+
+// fieldErrorLines maps the field of every FieldError in err to its line
+func fieldErrorLines(err error) map[string]int {
+	lines := map[string]int{}
+	var walk func(error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		var fe *FieldError
+		if errors.As(err, &fe) {
+			lines[fe.Field] = fe.Line
+		}
+	}
+	walk(err)
+	return lines
+}
+
+func TestParseErrorLines(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want map[string]int
+	}{
+		{
+			"toml",
+			`[window]
+width = 640
+
+[[faces]]
+type = "analog"
+
+[[faces]]
+type = "digital"
+zone = "Bad/Zone"
+wobble = true
+
+[faces.colors]
+on = "not a colour"
+glow = "#fff"
+`,
+			map[string]int{
+				"faces.1.zone":      9,
+				"faces.1.colors.on": 13,
+				"faces.wobble":      10,
+				"faces.colors.glow": 14,
+			},
+		},
+		{
+			"yaml",
+			`window:
+  width: 640
+faces:
+  - type: analog
+  - type: digital
+    zone: Bad/Zone
+    colors:
+      on: not a colour
+`,
+			map[string]int{
+				"faces.1.zone":      6,
+				"faces.1.colors.on": 8,
+			},
+		},
+	}
+	for _, tt := range tests {
+		_, err := Parse("go-clock."+tt.name, []byte(tt.src))
+		if err == nil {
+			t.Errorf("%s: got no error", tt.name)
+			continue
+		}
+		got := fieldErrorLines(err)
+		for field, line := range tt.want {
+			if got[field] != line {
+				t.Errorf("%s: %s: got line %d, want %d (%v)", tt.name, field, got[field], line, err)
+			}
+		}
+	}
+}
+
+func TestParseYAMLUnknownKey(t *testing.T) {
+	src := `faces:
+  - type: digital
+    colors:
+      glow: "#fff"
+`
+	_, err := Parse("go-clock.yaml", []byte(src))
+	if err == nil || !strings.Contains(err.Error(), "line 4") || !strings.Contains(err.Error(), "glow") {
+		t.Errorf("got %v, want an error naming glow on line 4", err)
+	}
+}
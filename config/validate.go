@@ -0,0 +1,348 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+	"time"
+
+	"temp.com/go-clock/animation"
+	"temp.com/go-clock/clock"
+	"temp.com/go-clock/utils"
+)
+
+// FieldError is a problem with a single setting, with the line it is on
+// when the config came from a file
+type FieldError struct {
+	File  string
+	Line  int
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%s:%d: %s: %v", e.File, e.Line, e.Field, e.Err)
+	case e.File != "":
+		return fmt.Sprintf("%s: %s: %v", e.File, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every setting and reports all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, c.fieldError(field, err))
+		}
+	}
+
+	if c.Window.Width <= 0 {
+		check("window.width", errors.New("must be greater than 0"))
+	}
+	if c.Window.Height <= 0 {
+		check("window.height", errors.New("must be greater than 0"))
+	}
+
+	for _, field := range c.Colors.check("colors", true) {
+		check(field.name, field.err)
+	}
+
+	for i, face := range c.Faces {
+		prefix := fmt.Sprintf("faces.%d", i)
+
+		if !slices.Contains(clock.FaceNames(), face.Type) {
+			check(prefix+".type", fmt.Errorf("unknown face %q, want one of %s", face.Type, strings.Join(clock.FaceNames(), ", ")))
+		}
+		if _, err := clock.LoadZone(face.Zone); err != nil {
+			check(prefix+".zone", err)
+		}
+		if face.Size < 0 {
+			check(prefix+".size", errors.New("must not be negative"))
+		}
+		if _, err := clock.ParseSeparator(face.Separator); err != nil {
+			check(prefix+".separator", err)
+		}
+		if _, err := clock.ParseColonBlink(face.ColonBlink); err != nil {
+			check(prefix+".colon_blink", err)
+		}
+		if face.DotMatrix != "" {
+			if _, err := clock.MatrixFontByName(face.DotMatrix); err != nil {
+				check(prefix+".dot_matrix", err)
+			}
+		}
+		if face.Aspect != 0 {
+			geometry := clock.NewDigitGeometry(faceGeometryWidth)
+			geometry.Height = faceGeometryWidth * face.Aspect
+			if err := geometry.Validate(); err != nil {
+				check(prefix+".aspect", err)
+			}
+		}
+		if face.Thickness != 0 {
+			geometry := clock.NewDigitGeometry(faceGeometryWidth)
+			geometry.Thickness = face.Thickness
+			if err := geometry.Validate(); err != nil {
+				check(prefix+".thickness", err)
+			}
+		}
+		geometry := clock.NewDigitGeometry(faceGeometryWidth)
+		geometry.Slant = face.Slant
+		if err := geometry.Validate(); err != nil {
+			check(prefix+".slant", err)
+		}
+		if _, err := clock.ParseSegmentStyle(face.SegmentStyle); err != nil {
+			check(prefix+".segment_style", err)
+		}
+		if _, err := face.AfterglowDuration(); err != nil {
+			check(prefix+".afterglow", err)
+		}
+		if _, err := clock.ParseDateMode(face.Date); err != nil {
+			check(prefix+".date", err)
+		}
+		// blame date_every when it is wrong on its own, else date_for
+		if _, _, err := (FaceConfig{DateEvery: face.DateEvery}).DateTiming(); err != nil {
+			check(prefix+".date_every", err)
+		} else if _, _, err := face.DateTiming(); err != nil {
+			check(prefix+".date_for", err)
+		}
+		for _, field := range face.Colors.check(prefix+".colors", false) {
+			check(field.name, field.err)
+		}
+	}
+
+	if _, err := animation.ParseTempo(c.Animation.Tempo); err != nil {
+		check("animation.tempo", err)
+	}
+	if c.Animation.Playlist != "" {
+		if _, err := c.Animation.Schedule(); err != nil {
+			field := "animation.playlist"
+			switch {
+			case c.Animation.Every != "":
+				field = "animation.every"
+			case len(c.Animation.At) > 0:
+				field = "animation.at"
+			}
+			check(field, err)
+		}
+	}
+	if _, err := c.Animation.CrossfadeDuration(); err != nil {
+		check("animation.crossfade", err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Schedule reads when the playlist switches
+func (ac AnimationConfig) Schedule() (animation.Schedule, error) {
+	return animation.ParseSchedule(ac.Every, ac.At)
+}
+
+// CrossfadeDuration reads the playlist crossfade, 0 when it is empty
+func (ac AnimationConfig) CrossfadeDuration() (time.Duration, error) {
+	if ac.Crossfade == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(ac.Crossfade)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid crossfade %q, want a duration such as 1s", ac.Crossfade)
+	}
+	return d, nil
+}
+
+type colorField struct {
+	name string
+	err  error
+}
+
+// check parses every colour, required colours must not be empty
+func (cc ColorConfig) check(prefix string, required bool) []colorField {
+	var bad []colorField
+	for _, f := range cc.fields() {
+		name, value := f[0], f[1]
+		if value == "" {
+			if required {
+				bad = append(bad, colorField{prefix + "." + name, errors.New("missing colour")})
+			}
+			continue
+		}
+		if _, err := utils.ParseHexColor(value); err != nil {
+			bad = append(bad, colorField{prefix + "." + name, err})
+		}
+	}
+	return bad
+}
+
+// fields lists the colours as name, value pairs in file order
+func (cc ColorConfig) fields() [][2]string {
+	return [][2]string{
+		{"on", cc.On}, {"off", cc.Off}, {"stroke", cc.Stroke},
+		{"second", cc.Second}, {"minute", cc.Minute}, {"hour", cc.Hour},
+	}
+}
+
+// Palette converts the colours to a clock palette, empty colours are left nil
+func (cc ColorConfig) Palette() (clock.Palette, error) {
+	var errs []error
+	parse := func(s string) color.Color {
+		if s == "" {
+			return nil
+		}
+		c, err := utils.ParseHexColor(s)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		return c
+	}
+
+	p := clock.Palette{
+		On:          parse(cc.On),
+		Off:         parse(cc.Off),
+		Stroke:      parse(cc.Stroke),
+		SecondColor: parse(cc.Second),
+		MinuteColor: parse(cc.Minute),
+		HourColor:   parse(cc.Hour),
+	}
+	return p, errors.Join(errs...)
+}
+
+// BoardEntries converts the faces to entries for clock.NewWorldClockBoard
+func (c *Config) BoardEntries() ([]clock.BoardEntry, error) {
+	entries := make([]clock.BoardEntry, 0, len(c.Faces))
+	for i, face := range c.Faces {
+		palette, err := face.Colors.Palette()
+		if err != nil {
+			return nil, c.fieldError(fmt.Sprintf("faces.%d.colors", i), err)
+		}
+		digital, err := face.digitalOptions()
+		if err != nil {
+			return nil, c.fieldError(fmt.Sprintf("faces.%d", i), err)
+		}
+		digital.LowPower = c.LowPower
+		entries = append(entries, clock.BoardEntry{
+			Label:    face.Label,
+			Zone:     face.Zone,
+			Face:     face.Type,
+			Sweep:    face.Sweep,
+			Size:     face.Size,
+			Mode24hr: face.Mode24h,
+			Palette:  palette,
+			Digital:  digital,
+		})
+	}
+	return entries, nil
+}
+
+// faceGeometryWidth is any digit width, only the proportions are checked
+const faceGeometryWidth = 70
+
+func (face FaceConfig) digitalOptions() (clock.DigitalOptions, error) {
+	separator, err := clock.ParseSeparator(face.Separator)
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	blink, err := clock.ParseColonBlink(face.ColonBlink)
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	style, err := clock.ParseSegmentStyle(face.SegmentStyle)
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	afterglow, err := face.AfterglowDuration()
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	date, err := clock.ParseDateMode(face.Date)
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	every, show, err := face.DateTiming()
+	if err != nil {
+		return clock.DigitalOptions{}, err
+	}
+	var font *clock.MatrixFont
+	if face.DotMatrix != "" {
+		if font, err = clock.MatrixFontByName(face.DotMatrix); err != nil {
+			return clock.DigitalOptions{}, err
+		}
+	}
+
+	return clock.DigitalOptions{
+		HideLeadingZero: face.HideLeadingZero,
+		HideSeconds:     face.HideSeconds,
+		Separator:       separator,
+		ColonBlink:      blink,
+		DimColon:        face.DimColon,
+		DotMatrix:       font,
+		Aspect:          face.Aspect,
+		Thickness:       face.Thickness,
+		Slant:           face.Slant,
+		SegmentStyle:    style,
+		Afterglow:       afterglow,
+		DateMode:        date,
+		DateEvery:       every,
+		DateFor:         show,
+		MonthFirst:      face.MonthFirst,
+	}, nil
+}
+
+// DateTiming reads date_every and date_for, empty values are 0 for the
+// clock's defaults
+func (face FaceConfig) DateTiming() (every, show time.Duration, err error) {
+	parse := func(name, value string) time.Duration {
+		if value == "" || err != nil {
+			return 0
+		}
+		d, perr := time.ParseDuration(value)
+		if perr != nil || d <= 0 {
+			err = fmt.Errorf("invalid %s %q, want a duration such as 30s", name, value)
+		}
+		return d
+	}
+	every = parse("date_every", face.DateEvery)
+	show = parse("date_for", face.DateFor)
+	if err != nil {
+		return 0, 0, err
+	}
+	return every, show, clock.ValidateDateTiming(every, show)
+}
+
+// AfterglowDuration reads the segment fade time, 0 when it is empty
+func (face FaceConfig) AfterglowDuration() (time.Duration, error) {
+	if face.Afterglow == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(face.Afterglow)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid afterglow %q, want a duration such as 150ms", face.Afterglow)
+	}
+	return d, nil
+}
+
+func (c *Config) fieldError(field string, err error) *FieldError {
+	return &FieldError{File: c.path, Line: c.line(field), Field: field, Err: err}
+}
+
+// line finds the line of field, falling back to its closest parent
+func (c *Config) line(field string) int {
+	for key := field; key != ""; {
+		if line, ok := c.lines[key]; ok {
+			return line
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return 0
+}
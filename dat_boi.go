@@ -1,65 +1,82 @@
 package main
 
 import (
-	"image/gif"
-	"os"
-	"time"
+	"context"
+	"embed"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
+	"temp.com/go-clock/animation"
+	"temp.com/go-clock/clock"
+	"temp.com/go-clock/config"
 )
 
-// AnimatedGIF holds the GIF frames + the image object
-type AnimatedGIF struct {
-	Image  *canvas.Image
-	frames []*canvas.Image
-	delays []time.Duration
-}
+// datBoiFS holds Dat Boi in the binary so it runs from any directory
+//
+//go:embed local/images/Dat_boi.gif
+var datBoiFS embed.FS
+
+const datBoiGIF = "local/images/Dat_boi.gif"
 
-// NewAnimatedGIF loads a GIF from file and prepares it
-func NewAnimatedGIF(path string) *AnimatedGIF {
-	file, err := os.Open(path)
+// loadAnimation opens path, or the embedded Dat Boi when no path is given
+func loadAnimation(path string) (*animation.Player, error) {
+	var a *animation.Animation
+	var err error
+	if path == "" {
+		a, err = animation.Open(datBoiFS, datBoiGIF)
+	} else {
+		a, err = animation.Load(path)
+	}
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	defer file.Close()
+	return animation.NewPlayer(a), nil
+}
 
-	g, err := gif.DecodeAll(file)
+// newMascot builds the animation panel and hooks it up to scheduler, a
+// playlist that cannot be read falls back to the single animation
+func newMascot(cfg config.AnimationConfig, source clock.TimeSource, scheduler *clock.Scheduler) (fyne.CanvasObject, func()) {
+	tempo, err := animation.ParseTempo(cfg.Tempo)
 	if err != nil {
-		panic(err)
+		fyne.LogError("could not read animation tempo", err)
 	}
 
-	var frames []*canvas.Image
-	var delays []time.Duration
-
-	for i, img := range g.Image {
-		frames = append(frames, canvas.NewImageFromImage(img))
-		delays = append(delays, time.Duration(g.Delay[i])*10*time.Millisecond)
+	if cfg.Playlist != "" {
+		playlist, err := newPlaylist(cfg)
+		if err == nil {
+			playlist.Tempo = tempo
+			scheduler.Register(playlist)
+			if tempo > 0 {
+				scheduler.RegisterFrame(playlist)
+			}
+			playlist.Start(context.Background(), source.Now())
+			return playlist.Container, playlist.Stop
+		}
+		fyne.LogError("could not load playlist, showing single animation", err)
 	}
 
-	initial := canvas.NewImageFromImage(g.Image[0])
-	initial.FillMode = canvas.ImageFillOriginal
-
-	return &AnimatedGIF{
-		Image:  initial,
-		frames: frames,
-		delays: delays,
+	datBoi, err := loadAnimation(cfg.Path)
+	if err != nil {
+		fyne.LogError("could not load animation, showing placeholder", err)
+		datBoi = animation.NewPlaceholder()
 	}
-}
+	if tempo > 0 {
+		// tick along with the clock instead of the GIF's own timing
+		datBoi.SetTempo(tempo)
+		scheduler.RegisterFrame(datBoi)
+	}
+	datBoi.Start(context.Background()) // start animation
 
-// Start begins animating the GIF in a loop
-func (a *AnimatedGIF) Start() {
-	go func() {
-		frame := 0
-		for {
-			time.Sleep(a.delays[frame])
-			frame = (frame + 1) % len(a.frames)
+	return datBoi.Image, datBoi.Stop
+}
 
-			fyne.Do(func() {
-				// swap frame image
-				a.Image.Image = a.frames[frame].Image
-				a.Image.Refresh()
-			})
-		}
-	}()
+func newPlaylist(cfg config.AnimationConfig) (*animation.Playlist, error) {
+	schedule, err := cfg.Schedule()
+	if err != nil {
+		return nil, err
+	}
+	crossfade, err := cfg.CrossfadeDuration()
+	if err != nil {
+		return nil, err
+	}
+	return animation.NewPlaylist(cfg.Playlist, schedule, crossfade)
 }
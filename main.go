@@ -1,8 +1,12 @@
 package main
 
 import (
-	"image/color"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
 	"time"
+	_ "time/tzdata" // IANA zones on systems without a zoneinfo database
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -11,72 +15,73 @@ import (
 )
 
 func main() {
+	opts, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := opts.loadConfig()
+	if err == nil {
+		err = opts.apply(cfg)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// convert the config before the app exists so a bad one exits non-zero
+	palette, err := cfg.Colors.Palette()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not read colours:", err)
+		os.Exit(1)
+	}
+	entries, err := cfg.BoardEntries()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not read faces:", err)
+		os.Exit(1)
+	}
+
 	a := app.New()
 	w := a.NewWindow("Its Clocking time!")
-	t := clock.NewTickData()
-	w.Resize(fyne.NewSize(800, 600)) // wider for GIF
-
-	const cx, cy, radius = 100, 100, 80
-	const numberOfClocks = 3
-
-	onColor := color.RGBA{R: 255, G: 150, B: 50, A: 255}
-	offColor := color.RGBA{R: 50, G: 50, B: 50, A: 255}
-	strokeColor := color.RGBA{R: 200, G: 100, B: 30, A: 255}
-
-	ringClockSecColor := color.RGBA{R: 0, G: 255, B: 100, A: 255}
-	ringClockMinColor := color.RGBA{R: 50, G: 150, B: 255, A: 255}
-	ringClockHrColor := color.RGBA{R: 255, G: 80, B: 80, A: 255}
-
-	const cxRing, cyRing, radiusRing = 100, 100, 80
-	const digitalWidth, digitalSpacing = 70, 10
-
-	analogClock := clock.NewAnalogClock(cx, cy, radius)
-	digitalClock := clock.NewDigitalClock(true, onColor, offColor, strokeColor, digitalWidth, digitalSpacing)
-	ringClock := clock.NewRingClock(cxRing, cyRing, radiusRing, ringClockSecColor, ringClockMinColor, ringClockHrColor, offColor, strokeColor)
-	ringClock.BackFillArcsContainer()
-
-	analogClockContainer := container.NewWithoutLayout(
-		analogClock.ClockFace,
-		analogClock.HourHand,
-		analogClock.MinuteHand,
-		analogClock.SecondHand,
-	)
-
-	digitalClockContainer := container.NewWithoutLayout(
-		digitalClock.ClockFace,
-	)
-
-	ringClockContainer := container.NewWithoutLayout(
-		ringClock.ClockFace,
-	)
-
-	clocks := container.NewGridWithRows(numberOfClocks,
-		analogClockContainer,
-		digitalClockContainer,
-		ringClockContainer,
-	)
-
-	datBoi := NewAnimatedGIF("local/images/Dat_boi.gif")
-	datBoi.Start() // start animation
-
-	content := container.NewGridWithColumns(2,
-		clocks,
-		datBoi.Image, // just add the *canvas.Image
-	)
-
-	w.SetContent(content)
-
-	// clock updater
-	go func() {
-		for range time.Tick(time.Second) {
-			fyne.Do(func() {
-				t.Update()
-				analogClock.Update(t)
-				digitalClock.Update(t)
-				ringClock.Update(t)
-			})
-		}
-	}()
+	source := clock.NewRealClock()
+	w.Resize(fyne.NewSize(float32(cfg.Window.Width), float32(cfg.Window.Height)))
+	w.SetFullScreen(opts.fullscreen)
+
+	board, err := clock.NewWorldClockBoard(source, entries, clock.DefaultPalette().Merge(palette), cfg.Mode24h)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not build clock board:", err)
+		os.Exit(1)
+	}
+
+	scheduler := clock.NewScheduler(source, time.Local)
+	scheduler.Register(board)
+	// the callbacks run on the scheduler goroutine, queued ahead of the tick
+	scheduler.OnMissed = func(int) { fyne.Do(board.Resync) }
+	scheduler.OnJump = func(time.Time, time.Time) { fyne.Do(board.Resync) }
+	if board.Sweeping() {
+		scheduler.RegisterFrame(clock.UpdaterFunc(board.UpdateFrame))
+	}
+
+	if opts.noGIF {
+		w.SetContent(board.Container)
+	} else {
+		mascot, stop := newMascot(cfg.Animation, source, scheduler)
+		defer stop()
+
+		content := container.NewGridWithColumns(2,
+			board.Container,
+			mascot,
+		)
+
+		w.SetContent(content)
+	}
+
+	scheduler.Start()
+	defer scheduler.Stop()
 
 	w.ShowAndRun()
 }
@@ -1,6 +1,11 @@
 package utils
 
-import "image/color"
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
 
 func DimColor(c color.Color, factor float64) color.Color {
 	r, g, b, a := c.RGBA()
@@ -12,6 +17,28 @@ func DimColor(c color.Color, factor float64) color.Color {
 	}
 }
 
+// ParseHexColor reads "#rgb", "#rrggbb" or "#rrggbbaa", the leading # is optional
+func ParseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, fmt.Errorf("invalid colour %q, want #rrggbb", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid colour %q, want #rrggbb", s)
+	}
+
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
 func DatBoiHandler() {
 
 }